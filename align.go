@@ -1,9 +1,9 @@
 package lipgloss
 
 import (
+	"math"
 	"strings"
 
-	"github.com/muesli/reflow/ansi"
 	"github.com/muesli/termenv"
 )
 
@@ -15,7 +15,7 @@ func alignText(str string, pos Position, width int, style *termenv.Style) string
 	var b strings.Builder
 
 	for i, l := range lines {
-		lineWidth := ansi.PrintableRuneWidth(l)
+		lineWidth := stringWidth(l)
 
 		shortAmount := widestLine - lineWidth                // difference from the widest line
 		shortAmount += max(0, width-(shortAmount+lineWidth)) // difference from the total width, if set
@@ -57,3 +57,89 @@ func alignText(str string, pos Position, width int, style *termenv.Style) string
 
 	return b.String()
 }
+
+// Perform vertical alignment by adding empty lines above and below the text
+// until it's the given height. The empty lines are filled in with whitespace
+// later, when aligning the text horizontally.
+func alignTextVertical(str string, pos Position, height int) string {
+	gap := height - (strings.Count(str, "\n") + 1)
+	if gap <= 0 {
+		return str
+	}
+
+	top := int(math.Round(float64(gap) * pos.value()))
+	bottom := gap - top
+
+	return strings.Repeat("\n", top) + str + strings.Repeat("\n", bottom)
+}
+
+// Justify a line of text by distributing the given number of extra spaces
+// between its words. Words are separated by runs of spaces; leading spaces and
+// the given number of cells at the start and end of the line, such as a
+// hanging indent, are kept as is. Each portion of text is rendered with the
+// given function and, if a termenv style is passed, it's used to style the
+// spaces added.
+func justifyLine(str string, extra, lead, trail int, render func(string) string, style *termenv.Style) string {
+	var (
+		gaps  []int // byte offsets of the ends of runs of spaces
+		space bool
+		words bool // whether we've seen a word yet
+		col   int
+		end   = stringWidth(str) - trail
+	)
+
+	for r := newClusterReader(str); r.next(); {
+		if r.seq {
+			continue
+		}
+		c := col
+		col += r.width
+		if c < lead {
+			continue
+		}
+		if c >= end {
+			break
+		}
+
+		if r.cluster == " " {
+			space = words
+			continue
+		}
+		if space {
+			gaps = append(gaps, r.start)
+			space = false
+		}
+		words = true
+	}
+
+	if extra < 1 || len(gaps) == 0 {
+		return render(str)
+	}
+
+	var (
+		b    strings.Builder
+		n    = extra / len(gaps)
+		rem  = extra % len(gaps)
+		prev int
+	)
+
+	for i, end := range gaps {
+		b.WriteString(render(str[prev:end]))
+
+		// Distribute the remainder over the leftmost gaps.
+		sp := n
+		if i < rem {
+			sp++
+		}
+		s := strings.Repeat(" ", sp)
+		if style != nil {
+			s = style.Styled(s)
+		}
+		b.WriteString(s)
+
+		prev = end
+	}
+	b.WriteString(render(str[prev:]))
+
+	return b.String()
+}
@@ -0,0 +1,461 @@
+package lipgloss
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Direction is the direction of text in a block.
+type Direction int
+
+// Available directions.
+const (
+	// DirectionLTR lays out text from left to right.
+	DirectionLTR Direction = iota
+
+	// DirectionRTL lays out text from right to left.
+	DirectionRTL
+
+	// DirectionAuto determines the direction from the first strongly
+	// directional character in each paragraph. Text without any is laid out
+	// from left to right.
+	DirectionAuto
+)
+
+// bidiClass is a bidirectional character type as defined in Unicode Standard
+// Annex #9. Explicit embeddings, overrides and isolates aren't supported, and
+// their formatting characters are treated as boundary neutrals.
+type bidiClass int
+
+// Bidirectional character types.
+const (
+	bidiL   bidiClass = iota // left to right
+	bidiR                    // right to left
+	bidiAL                   // Arabic letter
+	bidiEN                   // European number
+	bidiES                   // European separator
+	bidiET                   // European terminator
+	bidiAN                   // Arabic number
+	bidiCS                   // common separator
+	bidiNSM                  // nonspacing mark
+	bidiBN                   // boundary neutral
+	bidiB                    // paragraph separator
+	bidiS                    // segment separator
+	bidiWS                   // whitespace
+	bidiON                   // other neutral
+)
+
+// Runes whose bidirectional type isn't covered by the general rules in
+// bidiClassOf.
+var bidiClasses = map[rune]bidiClass{
+	'\t': bidiS, '\n': bidiB, '\v': bidiS, '\f': bidiWS, '\r': bidiB, ' ': bidiWS,
+	'#': bidiET, '$': bidiET, '%': bidiET, '+': bidiES, ',': bidiCS, '-': bidiES,
+	'.': bidiCS, '/': bidiCS, ':': bidiCS,
+
+	0x0085: bidiB, 0x00A0: bidiCS, 0x00AD: bidiBN, 0x00B0: bidiET, 0x00B1: bidiET,
+	0x00B2: bidiEN, 0x00B3: bidiEN, 0x00B9: bidiEN, 0x058F: bidiET, 0x0609: bidiET,
+	0x060A: bidiET, 0x060C: bidiCS, 0x061C: bidiAL, 0x066A: bidiET, 0x066B: bidiAN,
+	0x066C: bidiAN, 0x06DD: bidiAN, 0x08E2: bidiAN, 0x1680: bidiWS, 0x180E: bidiBN,
+	0x200E: bidiL, 0x200F: bidiR, 0x2028: bidiWS, 0x2029: bidiB, 0x202F: bidiCS,
+	0x2044: bidiCS, 0x205F: bidiWS, 0x2212: bidiES, 0x2213: bidiET, 0x3000: bidiWS,
+	0xFB29: bidiES, 0xFE50: bidiCS, 0xFE52: bidiCS, 0xFE55: bidiCS, 0xFE62: bidiES,
+	0xFE63: bidiES, 0xFE69: bidiET, 0xFE6A: bidiET, 0xFEFF: bidiBN, 0xFF0B: bidiES,
+	0xFF0C: bidiCS, 0xFF0D: bidiES, 0xFF0E: bidiCS, 0xFF0F: bidiCS, 0xFF1A: bidiCS,
+	0xFFE0: bidiET, 0xFFE1: bidiET, 0xFFE5: bidiET, 0xFFE6: bidiET,
+}
+
+// bidiClassOf returns the bidirectional character type of a rune.
+func bidiClassOf(r rune) bidiClass {
+	if c, ok := bidiClasses[r]; ok {
+		return c
+	}
+
+	switch {
+	case r >= '0' && r <= '9', r >= 0x06F0 && r <= 0x06F9,
+		r >= 0x2070 && r <= 0x2079, r >= 0x2080 && r <= 0x2089,
+		r >= 0xFF10 && r <= 0xFF19:
+		return bidiEN
+	case r >= 0x0660 && r <= 0x0669, r >= 0x0600 && r <= 0x0605:
+		return bidiAN
+	case r >= 0x00A2 && r <= 0x00A5, r >= 0x2030 && r <= 0x2034,
+		r >= 0x20A0 && r <= 0x20CF, r >= 0xFF03 && r <= 0xFF05:
+		return bidiET
+	case r < 0x20, r >= 0x7F && r <= 0x9F:
+		return bidiBN
+	case r >= 0x2000 && r <= 0x200A:
+		return bidiWS
+	case r >= 0x200B && r <= 0x200D, r >= 0x202A && r <= 0x202E,
+		r >= 0x2060 && r <= 0x206F:
+		return bidiBN
+	case unicode.In(r, unicode.Mn, unicode.Me):
+		return bidiNSM
+	case r >= 0x0590 && r <= 0x05FF, r >= 0x07C0 && r <= 0x085F,
+		r >= 0xFB1D && r <= 0xFB4F, r >= 0x10800 && r <= 0x10FFF,
+		r >= 0x1E800 && r <= 0x1EDFF:
+		return bidiR
+	case r >= 0x0600 && r <= 0x07BF, r >= 0x0860 && r <= 0x08FF,
+		r >= 0xFB50 && r <= 0xFDFF, r >= 0xFE70 && r <= 0xFEFE,
+		r >= 0x1EE00 && r <= 0x1EEFF:
+		return bidiAL
+	case unicode.In(r, unicode.L, unicode.Mc, unicode.Nd, unicode.Nl, unicode.Co):
+		return bidiL
+	case unicode.Is(unicode.Cf, r):
+		return bidiBN
+	}
+
+	return bidiON
+}
+
+// Characters which are replaced by their mirror image when they appear in
+// right-to-left text.
+var bidiMirrors = map[rune]rune{
+	'(': ')', ')': '(', '<': '>', '>': '<', '[': ']', ']': '[', '{': '}',
+	'}': '{', '«': '»', '»': '«', '‹': '›', '›': '‹', '⁅': '⁆', '⁆': '⁅',
+	'⁽': '⁾', '⁾': '⁽', '₍': '₎', '₎': '₍', '≤': '≥', '≥': '≤', '⟨': '⟩',
+	'⟩': '⟨', '〈': '〉', '〉': '〈', '《': '》', '》': '《', '「': '」',
+	'」': '「', '『': '』', '』': '『', '【': '】', '】': '【', '（': '）',
+	'）': '（', '［': '］', '］': '［', '｛': '｝', '｝': '｛',
+}
+
+// firstStrongDirection returns the direction of the first strongly
+// directional character in a string, as described in rules P2 and P3. ANSI
+// sequences are ignored. If there's no strong character ok is false.
+func firstStrongDirection(str string) (rtl bool, ok bool) {
+	for r := newClusterReader(str); r.next(); {
+		if r.seq {
+			continue
+		}
+		for _, c := range r.cluster {
+			switch bidiClassOf(c) {
+			case bidiL:
+				return false, true
+			case bidiR, bidiAL:
+				return true, true
+			}
+			break
+		}
+	}
+	return false, false
+}
+
+// isRTL returns whether text in the given direction is laid out from right
+// to left.
+func isRTL(str string, dir Direction) bool {
+	switch dir {
+	case DirectionRTL:
+		return true
+	case DirectionAuto:
+		rtl, _ := firstStrongDirection(str)
+		return rtl
+	}
+	return false
+}
+
+// bidiReorder reorders wrapped lines of text for display. Paragraphs are made
+// up of lines broken by wrapping, as reported by the wrapper; in automatic
+// mode each paragraph gets its direction from its first strong character,
+// which is reported for each line.
+func bidiReorder(lines []string, wrapped []bool, dir Direction) (reordered []string, rtl []bool) {
+	rtl = make([]bool, len(lines))
+	for start := 0; start < len(lines); {
+		end := start + 1
+		for end < len(lines) && end-1 < len(wrapped) && wrapped[end-1] {
+			end++
+		}
+
+		r := isRTL(strings.Join(lines[start:end], "\n"), dir)
+		for i := start; i < end; i++ {
+			lines[i] = reorderLine(lines[i], r)
+			rtl[i] = r
+		}
+
+		start = end
+	}
+
+	return lines, rtl
+}
+
+// A bidiRun is a grapheme cluster, together with the styles in effect for it,
+// and its bidirectional properties.
+type bidiRun struct {
+	str   string
+	style sgrState
+	seqs  string    // other ANSI sequences before the cluster
+	orig  bidiClass // type before resolution
+	class bidiClass
+	level int
+}
+
+// reorderLine reorders a single line of text from logical to visual order
+// following the Unicode Bidirectional Algorithm.
+func reorderLine(str string, rtl bool) string {
+	var (
+		runs  []bidiRun
+		seqs  strings.Builder
+		state sgrState
+		mixed = rtl
+		base  int
+	)
+	if rtl {
+		base = 1
+	}
+
+	for r := newClusterReader(str); r.next(); {
+		if r.seq {
+			// Styles are tracked rather than moved along with the clusters,
+			// so they stay on the characters they were applied to.
+			if isSGR(r.cluster) || strings.HasPrefix(r.cluster, "\x1b]8;") {
+				state.update(r.cluster)
+			} else {
+				seqs.WriteString(r.cluster)
+			}
+			continue
+		}
+		var first rune
+		for _, c := range r.cluster {
+			first = c
+			break
+		}
+		c := bidiClassOf(first)
+		if c == bidiR || c == bidiAL || c == bidiAN {
+			mixed = true
+		}
+		runs = append(runs, bidiRun{str: r.cluster, style: state, seqs: seqs.String(), orig: c, class: c})
+		seqs.Reset()
+	}
+
+	// Nothing to reorder in purely left-to-right text.
+	if !mixed || len(runs) == 0 {
+		return str
+	}
+
+	resolveWeakTypes(runs, base)
+	resolveNeutralTypes(runs, base)
+	resolveLevels(runs, base)
+
+	// Reverse any contiguous sequence of characters at a given level or
+	// higher, from the highest level down to the lowest odd level (L2).
+	highest, lowest := runs[0].level, runs[0].level
+	for _, r := range runs {
+		if r.level > highest {
+			highest = r.level
+		}
+		if r.level < lowest {
+			lowest = r.level
+		}
+	}
+	for level := highest; level >= lowest|1; level-- {
+		for i := 0; i < len(runs); {
+			if runs[i].level < level {
+				i++
+				continue
+			}
+			j := i
+			for j < len(runs) && runs[j].level >= level {
+				j++
+			}
+			for a, b := i, j-1; a < b; a, b = a+1, b-1 {
+				runs[a], runs[b] = runs[b], runs[a]
+			}
+			i = j
+		}
+	}
+
+	var (
+		b   strings.Builder
+		cur sgrState
+	)
+	for _, r := range runs {
+		if r.style != cur {
+			b.WriteString(cur.end())
+			b.WriteString(r.style.start())
+			cur = r.style
+		}
+		b.WriteString(r.seqs)
+
+		// Mirror characters in right-to-left runs (L4).
+		if r.level%2 == 1 {
+			r.str = strings.Map(func(c rune) rune {
+				if m, ok := bidiMirrors[c]; ok {
+					return m
+				}
+				return c
+			}, r.str)
+		}
+		b.WriteString(r.str)
+	}
+
+	// Leave the styles in effect at the end of the line as they were, so
+	// they carry over to the next line.
+	if cur != state {
+		b.WriteString(cur.end())
+		b.WriteString(state.start())
+	}
+	b.WriteString(seqs.String())
+
+	return b.String()
+}
+
+// resolveWeakTypes applies rules W1 through W7.
+func resolveWeakTypes(runs []bidiRun, base int) {
+	sos := bidiL
+	if base == 1 {
+		sos = bidiR
+	}
+
+	// W1: nonspacing marks and boundary neutrals take the type of the
+	// previous character.
+	prev := sos
+	for i := range runs {
+		if runs[i].class == bidiNSM || runs[i].class == bidiBN {
+			runs[i].class = prev
+		}
+		prev = runs[i].class
+	}
+
+	// W2: European numbers following Arabic letters become Arabic numbers.
+	// W3: Arabic letters become right-to-left.
+	strong := sos
+	for i := range runs {
+		switch runs[i].class {
+		case bidiL, bidiR:
+			strong = runs[i].class
+		case bidiAL:
+			strong = bidiAL
+			runs[i].class = bidiR
+		case bidiEN:
+			if strong == bidiAL {
+				runs[i].class = bidiAN
+			}
+		}
+	}
+
+	// W4: a single separator between two numbers of the same type takes
+	// their type.
+	for i := 1; i < len(runs)-1; i++ {
+		prev, next := runs[i-1].class, runs[i+1].class
+		switch {
+		case runs[i].class == bidiES && prev == bidiEN && next == bidiEN,
+			runs[i].class == bidiCS && prev == bidiEN && next == bidiEN:
+			runs[i].class = bidiEN
+		case runs[i].class == bidiCS && prev == bidiAN && next == bidiAN:
+			runs[i].class = bidiAN
+		}
+	}
+
+	// W5: terminators adjacent to European numbers become European numbers.
+	for i := 0; i < len(runs); {
+		if runs[i].class != bidiET {
+			i++
+			continue
+		}
+		j := i
+		for j < len(runs) && runs[j].class == bidiET {
+			j++
+		}
+		if i > 0 && runs[i-1].class == bidiEN || j < len(runs) && runs[j].class == bidiEN {
+			for k := i; k < j; k++ {
+				runs[k].class = bidiEN
+			}
+		}
+		i = j
+	}
+
+	// W6: remaining separators and terminators become other neutrals. W7:
+	// European numbers in left-to-right context become left-to-right.
+	strong = sos
+	for i := range runs {
+		switch runs[i].class {
+		case bidiES, bidiET, bidiCS:
+			runs[i].class = bidiON
+		case bidiL, bidiR:
+			strong = runs[i].class
+		case bidiEN:
+			if strong == bidiL {
+				runs[i].class = bidiL
+			}
+		}
+	}
+}
+
+// resolveNeutralTypes applies rules N1 and N2.
+func resolveNeutralTypes(runs []bidiRun, base int) {
+	e := bidiL
+	if base == 1 {
+		e = bidiR
+	}
+
+	// Numbers act as right-to-left characters for resolving neutrals.
+	strongOf := func(c bidiClass) bidiClass {
+		if c == bidiEN || c == bidiAN {
+			return bidiR
+		}
+		return c
+	}
+
+	for i := 0; i < len(runs); {
+		if !isNeutral(runs[i].class) {
+			i++
+			continue
+		}
+		j := i
+		for j < len(runs) && isNeutral(runs[j].class) {
+			j++
+		}
+
+		before, after := e, e
+		if i > 0 {
+			before = strongOf(runs[i-1].class)
+		}
+		if j < len(runs) {
+			after = strongOf(runs[j].class)
+		}
+
+		c := e
+		if before == after {
+			c = before
+		}
+		for k := i; k < j; k++ {
+			runs[k].class = c
+		}
+		i = j
+	}
+}
+
+func isNeutral(c bidiClass) bool {
+	return c == bidiB || c == bidiS || c == bidiWS || c == bidiON
+}
+
+// resolveLevels applies rules I1 and I2, and resets the level of trailing
+// whitespace to the paragraph level as described in L1.
+func resolveLevels(runs []bidiRun, base int) {
+	for i := range runs {
+		level := base
+		switch c := runs[i].class; {
+		case base%2 == 0 && c == bidiR:
+			level++
+		case base%2 == 0 && (c == bidiAN || c == bidiEN):
+			level += 2
+		case base%2 == 1 && (c == bidiL || c == bidiEN || c == bidiAN):
+			level++
+		}
+		runs[i].level = level
+	}
+
+	// Whitespace before segment separators and at the end of the line goes
+	// back to the paragraph level.
+	trailing := true
+	for i := len(runs) - 1; i >= 0; i-- {
+		switch runs[i].orig {
+		case bidiS, bidiB:
+			runs[i].level = base
+			trailing = true
+		case bidiWS, bidiBN:
+			if trailing {
+				runs[i].level = base
+			}
+		default:
+			trailing = false
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package lipgloss
+
+import "testing"
+
+func TestReorderStyledRTL(t *testing.T) {
+	tt := []struct {
+		name string
+		in   string
+		dir  Direction
+		want string
+	}{
+		{
+			name: "styled word",
+			in:   "\x1b[1mשלום\x1b[0m abc",
+			dir:  DirectionRTL,
+			want: "abc \x1b[1mםולש\x1b[0m",
+		},
+		{
+			name: "mirrored brackets around styled word",
+			in:   "(\x1b[1mשלום\x1b[0m) abc",
+			dir:  DirectionRTL,
+			want: "abc (\x1b[1mםולש\x1b[0m)",
+		},
+		{
+			name: "style spanning both directions",
+			in:   "\x1b[31mabc שלום\x1b[0m",
+			dir:  DirectionLTR,
+			want: "\x1b[31mabc םולש\x1b[0m",
+		},
+		{
+			name: "style left open",
+			in:   "\x1b[1mשלום abc",
+			dir:  DirectionRTL,
+			want: "\x1b[1mabc םולש\x1b[0m",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NewStyle().Direction(tc.dir).Render(tc.in)
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
@@ -3,7 +3,6 @@ package lipgloss
 import (
 	"strings"
 
-	"github.com/muesli/reflow/ansi"
 	"github.com/muesli/termenv"
 )
 
@@ -18,53 +17,80 @@ type Border struct {
 	TopRight    string
 	BottomRight string
 	BottomLeft  string
+
+	// Junctions, used where inner rules meet, such as in tables.
+	MiddleLeft   string
+	MiddleRight  string
+	Middle       string
+	MiddleTop    string
+	MiddleBottom string
 }
 
 var (
 	noBorder = Border{}
 
 	normalBorder = Border{
-		Top:         "─",
-		Bottom:      "─",
-		Left:        "│",
-		Right:       "│",
-		TopLeft:     "┌",
-		TopRight:    "┐",
-		BottomLeft:  "└",
-		BottomRight: "┘",
+		Top:          "─",
+		Bottom:       "─",
+		Left:         "│",
+		Right:        "│",
+		TopLeft:      "┌",
+		TopRight:     "┐",
+		BottomLeft:   "└",
+		BottomRight:  "┘",
+		MiddleLeft:   "├",
+		MiddleRight:  "┤",
+		Middle:       "┼",
+		MiddleTop:    "┬",
+		MiddleBottom: "┴",
 	}
 
 	roundedBorder = Border{
-		Top:         "─",
-		Bottom:      "─",
-		Left:        "│",
-		Right:       "│",
-		TopLeft:     "╭",
-		TopRight:    "╮",
-		BottomLeft:  "╰",
-		BottomRight: "╯",
+		Top:          "─",
+		Bottom:       "─",
+		Left:         "│",
+		Right:        "│",
+		TopLeft:      "╭",
+		TopRight:     "╮",
+		BottomLeft:   "╰",
+		BottomRight:  "╯",
+		MiddleLeft:   "├",
+		MiddleRight:  "┤",
+		Middle:       "┼",
+		MiddleTop:    "┬",
+		MiddleBottom: "┴",
 	}
 
 	thickBorder = Border{
-		Top:         "━",
-		Bottom:      "━",
-		Left:        "┃",
-		Right:       "┃",
-		TopLeft:     "┏",
-		TopRight:    "┓",
-		BottomLeft:  "┗",
-		BottomRight: "┛",
+		Top:          "━",
+		Bottom:       "━",
+		Left:         "┃",
+		Right:        "┃",
+		TopLeft:      "┏",
+		TopRight:     "┓",
+		BottomLeft:   "┗",
+		BottomRight:  "┛",
+		MiddleLeft:   "┣",
+		MiddleRight:  "┫",
+		Middle:       "╋",
+		MiddleTop:    "┳",
+		MiddleBottom: "┻",
 	}
 
 	doubleBorder = Border{
-		Top:         "═",
-		Bottom:      "═",
-		Left:        "║",
-		Right:       "║",
-		TopLeft:     "╔",
-		TopRight:    "╗",
-		BottomLeft:  "╚",
-		BottomRight: "╝",
+		Top:          "═",
+		Bottom:       "═",
+		Left:         "║",
+		Right:        "║",
+		TopLeft:      "╔",
+		TopRight:     "╗",
+		BottomLeft:   "╚",
+		BottomRight:  "╝",
+		MiddleLeft:   "╠",
+		MiddleRight:  "╣",
+		Middle:       "╬",
+		MiddleTop:    "╦",
+		MiddleBottom: "╩",
 	}
 )
 
@@ -90,18 +116,9 @@ func DoubleBorder() Border {
 	return doubleBorder
 }
 
-func (s Style) applyBorder(str string) string {
+func (s Style) applyBorder(str string, rtl bool) string {
 	var (
-		topSet    = s.isSet(borderTopKey)
-		rightSet  = s.isSet(borderRightKey)
-		bottomSet = s.isSet(borderBottomKey)
-		leftSet   = s.isSet(borderLeftKey)
-
-		border    = s.getAsBorderStyle(borderStyleKey)
-		hasTop    = s.getAsBool(borderTopKey, false)
-		hasRight  = s.getAsBool(borderRightKey, false)
-		hasBottom = s.getAsBool(borderBottomKey, false)
-		hasLeft   = s.getAsBool(borderLeftKey, false)
+		border, hasTop, hasRight, hasBottom, hasLeft = s.borderSides()
 
 		topFG    = s.getAsColor(borderTopForegroundKey)
 		rightFG  = s.getAsColor(borderRightForegroundKey)
@@ -114,13 +131,12 @@ func (s Style) applyBorder(str string) string {
 		leftBG   = s.getAsColor(borderLeftBackgroundKey)
 	)
 
-	// If a border is set and no sides have been specifically turned on or off
-	// render borders on all sides.
-	if border != noBorder && !(topSet || rightSet || bottomSet || leftSet) {
-		hasTop = true
-		hasRight = true
-		hasBottom = true
-		hasLeft = true
+	// In right-to-left blocks the left border goes on the right, and vice
+	// versa.
+	if rtl {
+		hasLeft, hasRight = hasRight, hasLeft
+		leftFG, rightFG = rightFG, leftFG
+		leftBG, rightBG = rightBG, leftBG
 	}
 
 	// If no border is set or all borders are been disabled, abort.
@@ -131,7 +147,7 @@ func (s Style) applyBorder(str string) string {
 	lines, width := getLines(str)
 
 	if hasLeft {
-		width += ansi.PrintableRuneWidth(border.Left)
+		width += stringWidth(border.Left)
 	}
 
 	// Figure out which corners we should actually be using based on which
@@ -194,7 +210,60 @@ func (s Style) applyBorder(str string) string {
 	return out.String()
 }
 
-// Render the horizontal (top or bottom) portion of a border.
+// borderSides returns the border style and which of its sides are rendered.
+func (s Style) borderSides() (border Border, top, right, bottom, left bool) {
+	var (
+		topSet    = s.isSet(borderTopKey)
+		rightSet  = s.isSet(borderRightKey)
+		bottomSet = s.isSet(borderBottomKey)
+		leftSet   = s.isSet(borderLeftKey)
+	)
+
+	border = s.getAsBorderStyle(borderStyleKey)
+	top = s.getAsBool(borderTopKey, false)
+	right = s.getAsBool(borderRightKey, false)
+	bottom = s.getAsBool(borderBottomKey, false)
+	left = s.getAsBool(borderLeftKey, false)
+
+	// If a border is set and no sides have been specifically turned on or off
+	// render borders on all sides.
+	if border != noBorder && !(topSet || rightSet || bottomSet || leftSet) {
+		top = true
+		right = true
+		bottom = true
+		left = true
+	}
+
+	return border, top, right, bottom, left
+}
+
+// borderSize returns the number of cells the border adds to each side of a
+// block.
+func (s Style) borderSize() (top, right, bottom, left int) {
+	border, hasTop, hasRight, hasBottom, hasLeft := s.borderSides()
+	if border == noBorder {
+		return 0, 0, 0, 0
+	}
+
+	if hasTop {
+		top = 1
+	}
+	if hasRight {
+		right = stringWidth(border.Right)
+	}
+	if hasBottom {
+		bottom = 1
+	}
+	if hasLeft {
+		left = stringWidth(border.Left)
+	}
+
+	return top, right, bottom, left
+}
+
+// Render the horizontal (top or bottom) portion of a border. If the middle
+// character can't fill the edge exactly, for instance when box drawing
+// characters are wide, the remaining gap is filled with spaces.
 func renderHorizontalEdge(left, middle, right string, width int) string {
 	if width < 1 {
 		return ""
@@ -204,15 +273,16 @@ func renderHorizontalEdge(left, middle, right string, width int) string {
 		middle = " "
 	}
 
-	leftWidth := ansi.PrintableRuneWidth(left)
-	midWidth := ansi.PrintableRuneWidth(middle)
-	rightWidth := ansi.PrintableRuneWidth(right)
+	leftWidth := stringWidth(left)
+	midWidth := max(1, stringWidth(middle))
 
 	out := strings.Builder{}
 	out.WriteString(left)
-	for i := leftWidth + rightWidth; i < width+rightWidth; i += midWidth {
+	i := leftWidth
+	for ; i+midWidth <= width; i += midWidth {
 		out.WriteString(middle)
 	}
+	out.WriteString(strings.Repeat(" ", max(0, width-i)))
 	out.WriteString(right)
 
 	return out.String()
@@ -0,0 +1,211 @@
+package lipgloss
+
+import (
+	"sort"
+	"strings"
+)
+
+// Layer is a block of text placed on a Canvas.
+type Layer struct {
+	content string
+	x, y, z int
+}
+
+// NewLayer returns a layer for a block of text, such as the output of
+// Style.Render, at the top left corner of the canvas.
+func NewLayer(str string) Layer {
+	return Layer{content: str}
+}
+
+// At sets the cell and line of the canvas at which the top left corner of
+// the layer is placed. Parts of the layer at negative coordinates are cut
+// off.
+func (l Layer) At(x, y int) Layer {
+	l.x = x
+	l.y = y
+	return l
+}
+
+// Z sets the z-order of the layer. Layers with a higher z are drawn on top of
+// layers with a lower one; layers with the same z are drawn in the order they
+// were added.
+func (l Layer) Z(z int) Layer {
+	l.z = z
+	return l
+}
+
+// Canvas composites layers of text on top of each other. Cells a layer
+// doesn't cover, like those past the end of a short line, are transparent, so
+// the layers below show through.
+//
+// Example:
+//
+//     c := lipgloss.NewCanvas(
+//         lipgloss.NewLayer(view),
+//         lipgloss.NewLayer(dialog).At(10, 4).Z(1),
+//         lipgloss.NewLayer(toast).At(50, 0).Z(2),
+//     )
+//
+//     fmt.Println(c.Render())
+//
+type Canvas struct {
+	width, height int
+	layers        []Layer
+}
+
+// NewCanvas returns a new canvas with the given layers.
+func NewCanvas(layers ...Layer) Canvas {
+	return Canvas{}.Layers(layers...)
+}
+
+// Layers adds layers to the canvas.
+func (c Canvas) Layers(layers ...Layer) Canvas {
+	c.layers = append(append([]Layer{}, c.layers...), layers...)
+	return c
+}
+
+// Size sets the size of the canvas. Layers are cut off where they reach
+// beyond it. By default, or when set to 0, the canvas is as large as its
+// layers need.
+func (c Canvas) Size(width, height int) Canvas {
+	c.width = max(0, width)
+	c.height = max(0, height)
+	return c
+}
+
+// String implements fmt.Stringer.
+func (c Canvas) String() string {
+	return c.Render()
+}
+
+// canvasCell is a cell of a Canvas. A wide character takes up two cells, the
+// second of which is marked as a continuation of the first.
+type canvasCell struct {
+	cluster string
+	style   sgrState
+	cont    bool
+}
+
+// Render flattens the layers into a single block, drawing them in z-order.
+// Every line of the block is as wide as the canvas. Tabs are expanded into
+// spaces according to DefaultTabWidth.
+func (c Canvas) Render() string {
+	layers := append([]Layer{}, c.layers...)
+	sort.SliceStable(layers, func(i, j int) bool { return layers[i].z < layers[j].z })
+
+	// Tabs are expanded first, as each layer is drawn cell by cell.
+	for i := range layers {
+		layers[i].content = expandTabs(normalizeNewlines(layers[i].content), DefaultTabWidth)
+	}
+
+	width, height := c.width, c.height
+	if width == 0 || height == 0 {
+		var w, h int
+		for _, l := range layers {
+			w = max(w, l.x+Width(l.content))
+			h = max(h, l.y+Height(l.content))
+		}
+		if width == 0 {
+			width = w
+		}
+		if height == 0 {
+			height = h
+		}
+	}
+
+	grid := make([][]canvasCell, height)
+	for y := range grid {
+		grid[y] = make([]canvasCell, width)
+	}
+
+	for _, l := range layers {
+		for i, line := range strings.Split(l.content, "\n") {
+			y := l.y + i
+			if y < 0 || y >= height {
+				continue
+			}
+			drawLine(grid[y], line, l.x)
+		}
+	}
+
+	var b strings.Builder
+	for y, row := range grid {
+		var cur sgrState
+		for _, cell := range row {
+			if cell.cont {
+				continue
+			}
+			if cell.style != cur {
+				b.WriteString(cur.end())
+				b.WriteString(cell.style.start())
+				cur = cell.style
+			}
+			if cell.cluster == "" {
+				b.WriteByte(' ')
+			} else {
+				b.WriteString(cell.cluster)
+			}
+		}
+		b.WriteString(cur.end())
+		if y < height-1 {
+			b.WriteByte('\n')
+		}
+	}
+
+	return b.String()
+}
+
+// drawLine draws a line of styled text onto a row of cells from the given
+// cell onwards.
+func drawLine(row []canvasCell, line string, x int) {
+	var state sgrState
+
+	// erase empties a cell, along with the other half of a wide character
+	// it's part of.
+	erase := func(i int) {
+		if row[i].cont && i > 0 {
+			row[i-1] = canvasCell{style: row[i-1].style}
+		}
+		if i+1 < len(row) && row[i+1].cont {
+			row[i+1] = canvasCell{style: row[i+1].style}
+		}
+		row[i] = canvasCell{}
+	}
+
+	for r := newClusterReader(line); r.next(); {
+		switch {
+		case r.seq:
+			state.update(r.cluster)
+			continue
+		case r.width == 0:
+			// Zero-width clusters are part of the cell before them.
+			i := x - 1
+			if i > 0 && i < len(row) && row[i].cont {
+				i--
+			}
+			if i >= 0 && i < len(row) {
+				row[i].cluster += r.cluster
+			}
+			continue
+		}
+
+		for i := x; i < x+r.width; i++ {
+			if i >= 0 && i < len(row) {
+				erase(i)
+			}
+		}
+		switch {
+		case x < 0 || x+r.width > len(row):
+			// A wide character cut in half by an edge becomes a space.
+			for i := max(0, x); i < min(len(row), x+r.width); i++ {
+				row[i] = canvasCell{cluster: " ", style: state}
+			}
+		default:
+			row[x] = canvasCell{cluster: r.cluster, style: state}
+			for i := x + 1; i < x+r.width; i++ {
+				row[i] = canvasCell{style: state, cont: true}
+			}
+		}
+		x += r.width
+	}
+}
@@ -0,0 +1,464 @@
+package lipgloss
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// FlexJustify determines how the items in a Row or Column are distributed
+// along its main axis when they don't fill it.
+type FlexJustify int
+
+// Available justifications.
+const (
+	// JustifyStart packs items at the start. This is the default.
+	JustifyStart FlexJustify = iota
+
+	// JustifyEnd packs items at the end.
+	JustifyEnd
+
+	// JustifyCenter packs items in the middle.
+	JustifyCenter
+
+	// JustifySpaceBetween distributes the free space between items, with
+	// the first and last items at the edges.
+	JustifySpaceBetween
+
+	// JustifySpaceAround distributes the free space around items, so the
+	// space at the edges is half the space between items.
+	JustifySpaceAround
+
+	// JustifySpaceEvenly distributes the free space evenly between items and
+	// the edges.
+	JustifySpaceEvenly
+)
+
+// FlexItem is an item in a Row or Column. Items start out at their basis,
+// which is their natural size unless set otherwise, and then grow or shrink
+// to fill the container.
+type FlexItem struct {
+	render func(width, height int) string
+	grow   float64
+	shrink float64
+	basis  int
+}
+
+// FlexBlock returns a flex item for a block of text, such as the output of
+// Style.Render. The block is padded with spaces when it's given more room
+// than it needs, and cut off when it's given less.
+func FlexBlock(str string) FlexItem {
+	return FlexFunc(func(int, int) string {
+		return str
+	})
+}
+
+// FlexStyle returns a flex item which renders a string with a style at the
+// size the item is given, so text wraps and the background and borders fill
+// the space. The minimum width and height of the style are kept, so if the
+// item is given less room than that, it's cut off.
+func FlexStyle(s Style, str string) FlexItem {
+	return FlexFunc(renderAtSize(s, str))
+}
+
+// renderAtSize returns a function which renders a string with a style so
+// that the whole block, including borders and margins, is the size it's
+// given. A width or height of 0 leaves the size as it is. The block is never
+// smaller than the minimum width and height of the style, so text wraps at
+// the minimum width rather than narrower; a layout which gives it less room
+// cuts it off.
+func renderAtSize(s Style, str string) func(width, height int) string {
+	return func(width, height int) string {
+		st := s.Copy()
+		if width > 0 {
+			st = st.Width(max(max(1, s.getAsInt(minWidthKey)), width-s.horizontalFrameSize()))
+		}
+		if height > 0 {
+			st = st.Height(max(max(1, s.getAsInt(minHeightKey)), height-s.verticalFrameSize()))
+		}
+		return st.Render(str)
+	}
+}
+
+// FlexFunc returns a flex item which is rendered by a function at the size
+// it's given. A width or height of 0 asks for the natural size. The Render
+// method of Row and Column has the right signature, so containers can be
+// nested.
+//
+// Example:
+//
+//     sidebar := lipgloss.Column(items...).Gap(1)
+//     layout := lipgloss.Row(
+//         lipgloss.FlexFunc(sidebar.Render).Basis(20),
+//         lipgloss.FlexStyle(main, content).Grow(1),
+//     )
+//
+func FlexFunc(render func(width, height int) string) FlexItem {
+	return FlexItem{render: render, shrink: 1}
+}
+
+// Grow sets how much of the free space the item takes, relative to the other
+// items. By default items don't grow.
+func (f FlexItem) Grow(g float64) FlexItem {
+	f.grow = math.Max(0, g)
+	return f
+}
+
+// Shrink sets how much the item shrinks, relative to the other items, when
+// there isn't enough room for all of them. Items shrink in proportion to
+// this value times their basis. The default is 1; 0 keeps the item from
+// shrinking.
+func (f FlexItem) Shrink(s float64) FlexItem {
+	f.shrink = math.Max(0, s)
+	return f
+}
+
+// Basis sets the size of the item along the main axis before growing or
+// shrinking. By default, or when set to 0, it's the natural size of the item.
+func (f FlexItem) Basis(n int) FlexItem {
+	f.basis = max(0, n)
+	return f
+}
+
+// Flex lays out items in a row or a column, like a CSS flexbox. Create one
+// with Row or Column.
+type Flex struct {
+	vertical bool
+	items    []FlexItem
+	gap      int
+	justify  FlexJustify
+	align    Position
+	stretch  bool
+}
+
+// Row returns a flex container which lays out items side by side.
+//
+// Example:
+//
+//     layout := lipgloss.Row(
+//         lipgloss.FlexStyle(sidebarStyle, menu).Basis(24).Shrink(0),
+//         lipgloss.FlexStyle(mainStyle, content).Grow(1),
+//     ).Gap(1).Stretch(true)
+//
+//     fmt.Println(layout.Render(termWidth, termHeight))
+//
+func Row(items ...FlexItem) Flex {
+	return Flex{items: items}
+}
+
+// Column returns a flex container which lays out items one above another.
+func Column(items ...FlexItem) Flex {
+	return Flex{vertical: true, items: items}
+}
+
+// Gap sets the number of cells between items.
+func (f Flex) Gap(n int) Flex {
+	f.gap = max(0, n)
+	return f
+}
+
+// Justify sets how items are distributed along the main axis when they don't
+// fill it, which happens when none of them grow.
+func (f Flex) Justify(j FlexJustify) Flex {
+	f.justify = j
+	return f
+}
+
+// Align sets where items are placed along the cross axis: vertically in a
+// row and horizontally in a column.
+func (f Flex) Align(p Position) Flex {
+	f.align = p
+	return f
+}
+
+// Stretch sets whether items are given the full size of the container along
+// the cross axis. Items made with FlexStyle then fill the container.
+func (f Flex) Stretch(v bool) Flex {
+	f.stretch = v
+	return f
+}
+
+// Render lays out the items within the given width and height, returning a
+// block exactly that size. A width or height of 0 makes the block as wide or
+// tall as the items need.
+func (f Flex) Render(width, height int) string {
+	if f.vertical {
+		return f.renderColumn(width, height)
+	}
+	return f.renderRow(width, height)
+}
+
+func (f Flex) renderRow(width, height int) string {
+	n := len(f.items)
+	if n == 0 {
+		return fitBlock("", width, height, Left, Top)
+	}
+
+	bases := make([]int, n)
+	for i, item := range f.items {
+		bases[i] = item.basis
+		if bases[i] == 0 {
+			bases[i] = Width(item.render(0, 0))
+		}
+	}
+
+	gaps := f.gap * (n - 1)
+	if width <= 0 {
+		width = sum(bases) + gaps
+	}
+	sizes := flexSizes(f.items, bases, width-gaps)
+	spaces := justifySpaces(f.justify, width-gaps-sum(sizes), n)
+
+	blocks := make([]string, n)
+	for i, item := range f.items {
+		if f.stretch {
+			blocks[i] = item.render(sizes[i], height)
+		} else {
+			blocks[i] = item.render(sizes[i], 0)
+		}
+	}
+	if height <= 0 {
+		for _, b := range blocks {
+			height = max(height, Height(b))
+		}
+	}
+
+	lines := make([][]string, n)
+	for i, b := range blocks {
+		lines[i] = strings.Split(fitBlock(b, sizes[i], height, Left, f.align), "\n")
+	}
+
+	var b strings.Builder
+	for j := 0; j < height; j++ {
+		var l strings.Builder
+		for i := range lines {
+			sp := spaces[i]
+			if i > 0 {
+				sp += f.gap
+			}
+			l.WriteString(strings.Repeat(" ", sp))
+			l.WriteString(lines[i][j])
+		}
+		b.WriteString(fitLine(l.String(), width))
+		if j < height-1 {
+			b.WriteRune('\n')
+		}
+	}
+
+	return b.String()
+}
+
+func (f Flex) renderColumn(width, height int) string {
+	n := len(f.items)
+	if n == 0 {
+		return fitBlock("", width, height, Left, Top)
+	}
+
+	// Items are as tall as they are at the width they'll be rendered at.
+	crossWidth := 0
+	if f.stretch {
+		crossWidth = width
+	}
+
+	bases := make([]int, n)
+	for i, item := range f.items {
+		bases[i] = item.basis
+		if bases[i] == 0 {
+			bases[i] = Height(item.render(crossWidth, 0))
+		}
+	}
+
+	gaps := f.gap * (n - 1)
+	if height <= 0 {
+		height = sum(bases) + gaps
+	}
+	sizes := flexSizes(f.items, bases, height-gaps)
+	spaces := justifySpaces(f.justify, height-gaps-sum(sizes), n)
+
+	blocks := make([]string, n)
+	for i, item := range f.items {
+		if sizes[i] > 0 {
+			blocks[i] = item.render(crossWidth, sizes[i])
+		}
+	}
+	if width <= 0 {
+		for _, b := range blocks {
+			width = max(width, Width(b))
+		}
+	}
+
+	var lines []string
+	blank := strings.Repeat(" ", width)
+	for i, b := range blocks {
+		sp := spaces[i]
+		if i > 0 {
+			sp += f.gap
+		}
+		for k := 0; k < sp; k++ {
+			lines = append(lines, blank)
+		}
+		if sizes[i] > 0 {
+			lines = append(lines, strings.Split(fitBlock(b, width, sizes[i], f.align, Top), "\n")...)
+		}
+	}
+	for len(lines) < height {
+		lines = append(lines, blank)
+	}
+
+	return strings.Join(lines[:height], "\n")
+}
+
+// flexSizes resolves the sizes of flex items along the main axis, growing or
+// shrinking them from their bases to fill the available space. Items which
+// would shrink below nothing are frozen at 0 and the remaining space is
+// shared among the others. Sizes are rounded so they add up to a whole.
+func flexSizes(items []FlexItem, bases []int, avail int) []int {
+	var (
+		n      = len(items)
+		exact  = make([]float64, n)
+		frozen = make([]bool, n)
+	)
+	for i, b := range bases {
+		exact[i] = float64(b)
+	}
+
+	for {
+		var total, used float64
+		for _, s := range exact {
+			used += s
+		}
+		free := float64(avail) - used
+
+		weights := make([]float64, n)
+		for i, item := range items {
+			switch {
+			case frozen[i]:
+			case free > 0:
+				weights[i] = item.grow
+			case free < 0:
+				weights[i] = item.shrink * float64(bases[i])
+			}
+			total += weights[i]
+		}
+		if math.Abs(free) < 1e-9 || total == 0 {
+			break
+		}
+
+		clamped := false
+		for i := range exact {
+			exact[i] += free * weights[i] / total
+			if exact[i] < 0 {
+				exact[i] = 0
+				frozen[i] = true
+				clamped = true
+			}
+		}
+		if !clamped {
+			break
+		}
+	}
+
+	return roundSizes(exact)
+}
+
+// roundSizes rounds sizes down, then hands out the cells left over to the
+// sizes with the largest fractions, so that the rounded sizes add up to the
+// rounded total.
+func roundSizes(exact []float64) []int {
+	var (
+		n     = len(exact)
+		sizes = make([]int, n)
+		whole float64
+		order = make([]int, n)
+	)
+	for i, s := range exact {
+		sizes[i] = int(math.Floor(s))
+		whole += s
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		fa := exact[order[a]] - math.Floor(exact[order[a]])
+		fb := exact[order[b]] - math.Floor(exact[order[b]])
+		return fa > fb
+	})
+	left := int(math.Floor(whole+0.5)) - sum(sizes)
+	for k := 0; k < left && k < n; k++ {
+		sizes[order[k]]++
+	}
+
+	return sizes
+}
+
+// justifySpaces returns the number of cells to put before each of n items to
+// distribute the free space according to the justification.
+func justifySpaces(j FlexJustify, free, n int) []int {
+	spaces := make([]int, n)
+	if free <= 0 || n == 0 {
+		return spaces
+	}
+
+	var lead, between float64
+	f := float64(free)
+	switch j {
+	case JustifyEnd:
+		lead = f
+	case JustifyCenter:
+		lead = f / 2
+	case JustifySpaceBetween:
+		if n > 1 {
+			between = f / float64(n-1)
+		}
+	case JustifySpaceAround:
+		between = f / float64(n)
+		lead = between / 2
+	case JustifySpaceEvenly:
+		between = f / float64(n+1)
+		lead = between
+	}
+
+	prev := 0
+	for i := range spaces {
+		pos := int(math.Floor(lead + float64(i)*between + 0.5))
+		spaces[i] = pos - prev
+		prev = pos
+	}
+
+	return spaces
+}
+
+// fitBlock pads or cuts off a block of text so it's exactly the given size,
+// placing it within that size at the given positions.
+func fitBlock(str string, width, height int, hPos, vPos Position) string {
+	if height <= 0 {
+		return ""
+	}
+
+	lines := strings.Split(str, "\n")
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	for i := range lines {
+		if stringWidth(lines[i]) > width {
+			lines[i] = truncateRight(lines[i], width)
+		}
+	}
+
+	str = alignTextVertical(strings.Join(lines, "\n"), vPos, height)
+	return alignText(str, hPos, width, nil)
+}
+
+// fitLine pads or cuts off a line so it's exactly the given width.
+func fitLine(str string, width int) string {
+	w := stringWidth(str)
+	if w > width {
+		return truncateRight(str, width)
+	}
+	return str + strings.Repeat(" ", width-w)
+}
+
+func sum(n []int) (s int) {
+	for _, v := range n {
+		s += v
+	}
+	return s
+}
@@ -1,10 +1,6 @@
 package lipgloss
 
-import (
-	"strings"
-
-	"github.com/muesli/reflow/ansi"
-)
+import "strings"
 
 // Returns whether or not the given property is set.
 func (s Style) isSet(k propKey) bool {
@@ -45,6 +41,17 @@ func (s Style) getAsInt(k propKey) int {
 	return 0
 }
 
+func (s Style) getAsFloat(k propKey) float64 {
+	v, ok := s.rules[k]
+	if !ok {
+		return 0
+	}
+	if f, ok := v.(float64); ok {
+		return f
+	}
+	return 0
+}
+
 func (s Style) getAsPosition(k propKey) Position {
 	v, ok := s.rules[k]
 	if !ok {
@@ -56,6 +63,50 @@ func (s Style) getAsPosition(k propKey) Position {
 	return Position(0)
 }
 
+func (s Style) getAsString(k propKey) string {
+	v, ok := s.rules[k]
+	if !ok {
+		return ""
+	}
+	if str, ok := v.(string); ok {
+		return str
+	}
+	return ""
+}
+
+func (s Style) getAsWrapMode(k propKey) WrapMode {
+	v, ok := s.rules[k]
+	if !ok {
+		return WrapWords
+	}
+	if m, ok := v.(WrapMode); ok {
+		return m
+	}
+	return WrapWords
+}
+
+func (s Style) getAsWhiteSpace(k propKey) WhiteSpace {
+	v, ok := s.rules[k]
+	if !ok {
+		return WhiteSpacePreWrap
+	}
+	if w, ok := v.(WhiteSpace); ok {
+		return w
+	}
+	return WhiteSpacePreWrap
+}
+
+func (s Style) getAsDirection(k propKey) Direction {
+	v, ok := s.rules[k]
+	if !ok {
+		return DirectionLTR
+	}
+	if d, ok := v.(Direction); ok {
+		return d
+	}
+	return DirectionLTR
+}
+
 func (s Style) getAsBorderStyle(k propKey) Border {
 	v, ok := s.rules[k]
 	if !ok {
@@ -67,13 +118,28 @@ func (s Style) getAsBorderStyle(k propKey) Border {
 	return noBorder
 }
 
+// horizontalFrameSize returns the number of cells the border and margins add
+// to the width of a block, which, unlike padding, aren't included in the
+// width set on a style.
+func (s Style) horizontalFrameSize() int {
+	_, right, _, left := s.borderSize()
+	return left + right + s.getAsInt(marginLeftKey) + s.getAsInt(marginRightKey)
+}
+
+// verticalFrameSize returns the number of lines the border and margins add to
+// the height of a block.
+func (s Style) verticalFrameSize() int {
+	top, _, bottom, _ := s.borderSize()
+	return top + bottom + s.getAsInt(marginTopKey) + s.getAsInt(marginBottomKey)
+}
+
 // Split a string into lines, additionally returning the size of the widest
-// line.
+// line. Line endings are normalized first.
 func getLines(s string) (lines []string, widest int) {
-	lines = strings.Split(s, "\n")
+	lines = strings.Split(normalizeNewlines(s), "\n")
 
 	for _, l := range lines {
-		w := ansi.PrintableRuneWidth(l)
+		w := stringWidth(l)
 		if widest < w {
 			widest = w
 		}
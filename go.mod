@@ -4,7 +4,9 @@ go 1.15
 
 require (
 	github.com/lucasb-eyer/go-colorful v1.2.0
+	github.com/mattn/go-runewidth v0.0.10
 	github.com/muesli/reflow v0.2.1-0.20210115123740-9e1d0d53df68
 	github.com/muesli/termenv v0.8.1
+	github.com/rivo/uniseg v0.2.0
 	golang.org/x/sys v0.0.0-20210119212857-b64e53b001e4 // indirect
 )
@@ -0,0 +1,342 @@
+package lipgloss
+
+import (
+	"sort"
+	"strings"
+)
+
+type trackKind int
+
+const (
+	trackAuto trackKind = iota
+	trackFixed
+	trackFraction
+)
+
+// Track is the size of a column or row in a Grid.
+type Track struct {
+	kind trackKind
+	size int
+	fr   float64
+}
+
+// Fixed returns a track of a fixed number of cells.
+func Fixed(n int) Track {
+	return Track{kind: trackFixed, size: max(0, n)}
+}
+
+// Fr returns a track which takes a fraction of the space left over by the
+// other tracks. Space is shared among fraction tracks in proportion to their
+// values, so Fr(2) is twice the size of Fr(1).
+func Fr(f float64) Track {
+	if f < 0 {
+		f = 0
+	}
+	return Track{kind: trackFraction, fr: f}
+}
+
+// Auto returns a track which is as large as the largest cell in it.
+func Auto() Track {
+	return Track{kind: trackAuto}
+}
+
+// GridCell is a cell in a Grid. It covers one or more columns and rows,
+// which together make up its area.
+type GridCell struct {
+	row, col         int
+	rowSpan, colSpan int
+	hPos, vPos       Position
+	render           func(width, height int) string
+}
+
+// GridBlock returns a cell at the given row and column for a block of text,
+// such as the output of Style.Render. The block is placed within the area of
+// the cell, and cut off if it doesn't fit.
+func GridBlock(row, col int, str string) GridCell {
+	return GridFunc(row, col, func(int, int) string {
+		return str
+	})
+}
+
+// GridStyle returns a cell at the given row and column which renders a string
+// with a style at the size of its area, so text wraps and the background and
+// borders fill the area. The minimum width and height of the style are kept,
+// so if the area is smaller than that, the cell is cut off.
+func GridStyle(row, col int, s Style, str string) GridCell {
+	return GridFunc(row, col, renderAtSize(s, str))
+}
+
+// GridFunc returns a cell at the given row and column which is rendered by a
+// function at the size of its area. A width or height of 0 asks for the
+// natural size, which is used to size auto tracks. Layouts, like Grid and
+// Flex, can be nested this way.
+func GridFunc(row, col int, render func(width, height int) string) GridCell {
+	return GridCell{
+		row:     max(0, row),
+		col:     max(0, col),
+		rowSpan: 1,
+		colSpan: 1,
+		render:  render,
+	}
+}
+
+// Span sets the number of rows and columns the cell spans.
+func (c GridCell) Span(rows, cols int) GridCell {
+	c.rowSpan = max(1, rows)
+	c.colSpan = max(1, cols)
+	return c
+}
+
+// Align sets where the content is placed within the area of the cell when
+// it's smaller than the area.
+func (c GridCell) Align(h, v Position) GridCell {
+	c.hPos = h
+	c.vPos = v
+	return c
+}
+
+// Grid lays out cells in columns and rows. Columns and rows which aren't
+// defined, but which have cells in them, are sized automatically.
+//
+// Example:
+//
+//     dashboard := lipgloss.NewGrid().
+//         Columns(lipgloss.Fixed(24), lipgloss.Fr(1), lipgloss.Fr(1)).
+//         Rows(lipgloss.Auto(), lipgloss.Fr(1)).
+//         Gap(0, 1).
+//         Cells(
+//             lipgloss.GridStyle(0, 0, titleStyle, "Dashboard").Span(1, 3),
+//             lipgloss.GridStyle(1, 0, panelStyle, menu),
+//             lipgloss.GridStyle(1, 1, panelStyle, stats),
+//             lipgloss.GridStyle(1, 2, panelStyle, logs),
+//         )
+//
+//     fmt.Println(dashboard.Render(termWidth, termHeight))
+//
+type Grid struct {
+	columns   []Track
+	rows      []Track
+	rowGap    int
+	columnGap int
+	cells     []GridCell
+}
+
+// NewGrid returns a new, empty grid.
+func NewGrid() Grid {
+	return Grid{}
+}
+
+// Columns sets the sizes of the columns.
+func (g Grid) Columns(tracks ...Track) Grid {
+	g.columns = tracks
+	return g
+}
+
+// Rows sets the sizes of the rows.
+func (g Grid) Rows(tracks ...Track) Grid {
+	g.rows = tracks
+	return g
+}
+
+// Gap sets the number of lines between rows and the number of cells between
+// columns.
+func (g Grid) Gap(rows, columns int) Grid {
+	g.rowGap = max(0, rows)
+	g.columnGap = max(0, columns)
+	return g
+}
+
+// Cells adds cells to the grid. Where cells overlap, the cell added first is
+// shown.
+func (g Grid) Cells(cells ...GridCell) Grid {
+	g.cells = append(append([]GridCell{}, g.cells...), cells...)
+	return g
+}
+
+// Render lays out the grid within the given width and height, returning a
+// block exactly that size. A width or height of 0 makes the block as wide or
+// tall as the cells need, in which case fraction tracks are sized like auto
+// tracks.
+func (g Grid) Render(width, height int) string {
+	columns, rows := g.columns, g.rows
+	for _, c := range g.cells {
+		for len(columns) < c.col+c.colSpan {
+			columns = append(columns, Auto())
+		}
+		for len(rows) < c.row+c.rowSpan {
+			rows = append(rows, Auto())
+		}
+	}
+
+	// Columns first, then rows, since the height of a cell depends on its
+	// width.
+	colSizes := resolveTracks(columns, width, g.columnGap, g.cells,
+		func(c GridCell) (int, int) { return c.col, c.colSpan },
+		func(c GridCell) int { return Width(c.render(0, 0)) },
+	)
+	colX := trackOffsets(colSizes, g.columnGap)
+
+	rowSizes := resolveTracks(rows, height, g.rowGap, g.cells,
+		func(c GridCell) (int, int) { return c.row, c.rowSpan },
+		func(c GridCell) int {
+			w := spanSize(colSizes, c.col, c.colSpan, g.columnGap)
+			return Height(c.render(w, 0))
+		},
+	)
+	rowY := trackOffsets(rowSizes, g.rowGap)
+
+	if width <= 0 {
+		width = max(0, sum(colSizes)+g.columnGap*(len(colSizes)-1))
+	}
+	if height <= 0 {
+		height = max(0, sum(rowSizes)+g.rowGap*(len(rowSizes)-1))
+	}
+
+	// Render each cell at the size of its area.
+	type area struct {
+		x, y, w, h int
+		lines      []string
+	}
+	var areas []area
+	for _, c := range g.cells {
+		a := area{
+			x: colX[c.col],
+			y: rowY[c.row],
+			w: spanSize(colSizes, c.col, c.colSpan, g.columnGap),
+			h: spanSize(rowSizes, c.row, c.rowSpan, g.rowGap),
+		}
+		if a.w == 0 || a.h == 0 {
+			continue
+		}
+		block := fitBlock(c.render(a.w, a.h), a.w, a.h, c.hPos, c.vPos)
+		a.lines = strings.Split(block, "\n")
+		areas = append(areas, a)
+	}
+
+	var b strings.Builder
+	for y := 0; y < height; y++ {
+		var row []area
+		for _, a := range areas {
+			if y >= a.y && y < a.y+a.h {
+				row = append(row, a)
+			}
+		}
+		sort.SliceStable(row, func(i, j int) bool { return row[i].x < row[j].x })
+
+		var (
+			l strings.Builder
+			x int
+		)
+		for _, a := range row {
+			if a.x < x {
+				continue // overlaps an earlier cell
+			}
+			l.WriteString(strings.Repeat(" ", a.x-x))
+			l.WriteString(a.lines[y-a.y])
+			x = a.x + a.w
+		}
+		b.WriteString(fitLine(l.String(), width))
+		if y < height-1 {
+			b.WriteRune('\n')
+		}
+	}
+
+	return b.String()
+}
+
+// resolveTracks works out the sizes of tracks along one axis. Fixed tracks
+// get their size and auto tracks the size of the largest cell in them, with
+// cells spanning several tracks sharing out what they need among the auto
+// tracks they span. Fraction tracks share whatever space is left.
+func resolveTracks(tracks []Track, total, gap int, cells []GridCell,
+	span func(GridCell) (start, n int), size func(GridCell) int) []int {
+	var (
+		sizes = make([]int, len(tracks))
+		auto  = func(i int) bool {
+			return tracks[i].kind == trackAuto || tracks[i].kind == trackFraction && total <= 0
+		}
+	)
+
+	for i, t := range tracks {
+		if t.kind == trackFixed {
+			sizes[i] = t.size
+		}
+	}
+
+	// Cells in a single track first, then spanning cells.
+	for _, c := range cells {
+		if start, n := span(c); n == 1 && auto(start) {
+			sizes[start] = max(sizes[start], size(c))
+		}
+	}
+	for _, c := range cells {
+		start, n := span(c)
+		if n == 1 {
+			continue
+		}
+		var autos []int
+		for i := start; i < start+n; i++ {
+			if auto(i) {
+				autos = append(autos, i)
+			}
+		}
+		need := size(c) - spanSize(sizes, start, n, gap)
+		if need <= 0 || len(autos) == 0 {
+			continue
+		}
+		for k, i := range autos {
+			share := need / len(autos)
+			if k < need%len(autos) {
+				share++
+			}
+			sizes[i] += share
+		}
+	}
+
+	if total <= 0 {
+		return sizes
+	}
+
+	// Share out the remaining space among fraction tracks.
+	var (
+		frs    []int
+		weight float64
+	)
+	for i, t := range tracks {
+		if t.kind == trackFraction {
+			frs = append(frs, i)
+			weight += t.fr
+		}
+	}
+	free := total - sum(sizes) - gap*max(0, len(tracks)-1)
+	if free <= 0 || weight == 0 {
+		return sizes
+	}
+	exact := make([]float64, len(frs))
+	for k, i := range frs {
+		exact[k] = float64(free) * tracks[i].fr / weight
+	}
+	for k, s := range roundSizes(exact) {
+		sizes[frs[k]] = s
+	}
+
+	return sizes
+}
+
+// trackOffsets returns where each track starts.
+func trackOffsets(sizes []int, gap int) []int {
+	offsets := make([]int, len(sizes))
+	for i := 1; i < len(sizes); i++ {
+		offsets[i] = offsets[i-1] + sizes[i-1] + gap
+	}
+	return offsets
+}
+
+// spanSize returns the size of n tracks, and the gaps between them, from the
+// given track onwards.
+func spanSize(sizes []int, start, n, gap int) int {
+	if n < 1 {
+		return 0
+	}
+	return sum(sizes[start:start+n]) + gap*(n-1)
+}
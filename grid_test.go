@@ -0,0 +1,29 @@
+package lipgloss
+
+import "testing"
+
+func TestGridSpanningCells(t *testing.T) {
+	tt := []struct {
+		name  string
+		cols  int
+		span  string
+		width int
+	}{
+		{"two columns", 2, "0123456789", 10},
+		{"three columns", 3, "012345678901", 12},
+		{"narrower than the columns", 2, "x", 3},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			cells := []GridCell{GridBlock(0, 0, tc.span).Span(1, tc.cols)}
+			for c := 0; c < tc.cols; c++ {
+				cells = append(cells, GridBlock(1, c, string(rune('a'+c))))
+			}
+			got := NewGrid().Gap(0, 1).Cells(cells...).Render(0, 0)
+			if w := Width(got); w != tc.width {
+				t.Errorf("got width %d, want %d:\n%s", w, tc.width, got)
+			}
+		})
+	}
+}
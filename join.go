@@ -3,8 +3,6 @@ package lipgloss
 import (
 	"math"
 	"strings"
-
-	"github.com/muesli/reflow/ansi"
 )
 
 // JoinHorizontal is a utility function for horizontally joining two
@@ -15,6 +13,8 @@ import (
 // If you just want to align to the left, right or center you may as well just
 // use the helper constants Top, Center, and Bottom.
 //
+// Tabs are expanded into spaces according to DefaultTabWidth.
+//
 // Example:
 //
 //     blockB := "...\n...\n..."
@@ -27,9 +27,32 @@ import (
 //     str := lipgloss.JoinHorizontal(lipgloss.Top, blockA, blockB)
 //
 func JoinHorizontal(pos Position, strs ...string) string {
+	return JoinHorizontalWith(pos, strs)
+}
+
+// JoinHorizontalWith joins strings horizontally like JoinHorizontal, filling
+// the space around shorter blocks according to the given options, so the
+// filler can match the background of the blocks. WithGap and WithSeparator
+// put space or a separator between the blocks.
+//
+// Example:
+//
+//     str := lipgloss.JoinHorizontalWith(lipgloss.Top, []string{blockA, blockB},
+//         lipgloss.WithWhitespaceBackground(lipgloss.Color("236")),
+//         lipgloss.WithSeparator("│"),
+//         lipgloss.WithGap(1),
+//     )
+//
+func JoinHorizontalWith(pos Position, strs []string, opts ...WhitespaceOption) string {
 	if len(strs) == 0 {
 		return ""
 	}
+
+	ws := &whitespace{}
+	for _, opt := range opts {
+		opt(ws)
+	}
+
 	if len(strs) == 1 {
 		return strs[0]
 	}
@@ -45,9 +68,10 @@ func JoinHorizontal(pos Position, strs ...string) string {
 		maxHeight int
 	)
 
-	// Break text blocks into lines and get max widths for each text block
+	// Break text blocks into lines and get max widths for each text block.
+	// Tabs are expanded first, as their tab stops would shift once joined.
 	for i, str := range strs {
-		blocks[i], maxWidths[i] = getLines(str)
+		blocks[i], maxWidths[i] = getLines(expandTabs(str, DefaultTabWidth))
 		if len(blocks[i]) > maxHeight {
 			maxHeight = len(blocks[i])
 		}
@@ -83,10 +107,18 @@ func JoinHorizontal(pos Position, strs ...string) string {
 	var b strings.Builder
 	for i := range blocks[0] { // remember, all blocks have the same number of members now
 		for j, block := range blocks {
+			if j > 0 {
+				b.WriteString(ws.fill(ws.gap))
+				if ws.separator != "" {
+					b.WriteString(ws.separator)
+					b.WriteString(ws.fill(ws.gap))
+				}
+			}
+
 			b.WriteString(block[i])
 
 			// Also make lines the same length
-			b.WriteString(strings.Repeat(" ", maxWidths[j]-ansi.PrintableRuneWidth(block[i])))
+			b.WriteString(ws.fill(maxWidths[j] - stringWidth(block[i])))
 		}
 		if i < len(blocks[0])-1 {
 			b.WriteRune('\n')
@@ -104,6 +136,8 @@ func JoinHorizontal(pos Position, strs ...string) string {
 // If you just want to align to the left, right or center you may as well just
 // use the helper constants Left, Center, and Right.
 //
+// Tabs are expanded into spaces according to DefaultTabWidth.
+//
 // Example:
 //
 //     blockB := "...\n...\n..."
@@ -116,9 +150,31 @@ func JoinHorizontal(pos Position, strs ...string) string {
 //     str := lipgloss.JoinVertical(lipgloss.Right, blockA, blockB)
 //
 func JoinVertical(pos Position, strs ...string) string {
+	return JoinVerticalWith(pos, strs)
+}
+
+// JoinVerticalWith joins strings vertically like JoinVertical, filling the
+// space beside narrower blocks according to the given options. WithGap puts
+// blank lines between the blocks, and WithSeparator a line with the
+// separator repeated across the width.
+//
+// Example:
+//
+//     str := lipgloss.JoinVerticalWith(lipgloss.Center, []string{blockA, blockB},
+//         lipgloss.WithWhitespaceBackground(lipgloss.Color("236")),
+//         lipgloss.WithSeparator("─"),
+//     )
+//
+func JoinVerticalWith(pos Position, strs []string, opts ...WhitespaceOption) string {
 	if len(strs) == 0 {
 		return ""
 	}
+
+	ws := &whitespace{}
+	for _, opt := range opts {
+		opt(ws)
+	}
+
 	if len(strs) == 1 {
 		return strs[0]
 	}
@@ -130,24 +186,41 @@ func JoinVertical(pos Position, strs ...string) string {
 
 	for i := range strs {
 		var w int
-		blocks[i], w = getLines(strs[i])
+		blocks[i], w = getLines(expandTabs(strs[i], DefaultTabWidth))
 		if w > maxWidth {
 			maxWidth = w
 		}
 	}
 
+	// Lines between blocks
+	var between []string
+	for k := 0; k < ws.gap; k++ {
+		between = append(between, ws.fill(maxWidth))
+	}
+	if ws.separator != "" {
+		between = append(between, repeatSeparator(ws.separator, maxWidth, ws))
+		between = append(between, between[:ws.gap]...)
+	}
+
 	var b strings.Builder
 	for i, block := range blocks {
+		if i > 0 {
+			for _, line := range between {
+				b.WriteString(line)
+				b.WriteRune('\n')
+			}
+		}
+
 		for j, line := range block {
-			w := maxWidth - ansi.PrintableRuneWidth(line)
+			w := maxWidth - stringWidth(line)
 
 			switch pos {
 			case Left:
 				b.WriteString(line)
-				b.WriteString(strings.Repeat(" ", w))
+				b.WriteString(ws.fill(w))
 
 			case Right:
-				b.WriteString(strings.Repeat(" ", w))
+				b.WriteString(ws.fill(w))
 				b.WriteString(line)
 
 			default: // Somewhere in the middle
@@ -160,9 +233,9 @@ func JoinVertical(pos Position, strs ...string) string {
 				left := w - split
 				right := w - left
 
-				b.WriteString(strings.Repeat(" ", left))
+				b.WriteString(ws.fill(left))
 				b.WriteString(line)
-				b.WriteString(strings.Repeat(" ", right))
+				b.WriteString(ws.fill(right))
 			}
 
 			// Write a newline as long as we're not on the last line of the
@@ -175,3 +248,13 @@ func JoinVertical(pos Position, strs ...string) string {
 
 	return b.String()
 }
+
+// repeatSeparator repeats a separator across the given width, filling any
+// cells left over with whitespace.
+func repeatSeparator(sep string, width int, ws *whitespace) string {
+	w := stringWidth(sep)
+	if w == 0 {
+		return ws.fill(width)
+	}
+	return strings.Repeat(sep, width/w) + ws.fill(width%w)
+}
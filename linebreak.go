@@ -0,0 +1,417 @@
+package lipgloss
+
+import "unicode"
+
+// breakClass is a line breaking class as defined in Unicode Standard Annex #14.
+// Only the classes relevant to terminal output are distinguished; the
+// remaining ones are resolved as described in rule LB1.
+type breakClass int
+
+// Line breaking classes.
+const (
+	lbAL  breakClass = iota // alphabetic and other characters
+	lbBA                    // break after
+	lbBB                    // break before
+	lbB2                    // break opportunity before and after
+	lbBK                    // mandatory break
+	lbCB                    // contingent break opportunity
+	lbCL                    // close punctuation
+	lbCM                    // combining mark
+	lbCP                    // close parenthesis
+	lbCR                    // carriage return
+	lbEB                    // emoji base
+	lbEM                    // emoji modifier
+	lbEX                    // exclamation and interrogation
+	lbGL                    // non-breaking ("glue")
+	lbH2                    // Hangul LV syllable
+	lbH3                    // Hangul LVT syllable
+	lbHY                    // hyphen
+	lbID                    // ideographic
+	lbIN                    // inseparable
+	lbIS                    // infix numeric separator
+	lbJL                    // Hangul L jamo
+	lbJT                    // Hangul T jamo
+	lbJV                    // Hangul V jamo
+	lbLF                    // line feed
+	lbNL                    // next line
+	lbNS                    // nonstarter
+	lbNU                    // numeric
+	lbOP                    // open punctuation
+	lbPO                    // postfix numeric
+	lbPR                    // prefix numeric
+	lbQU                    // quotation
+	lbRI                    // regional indicator
+	lbSP                    // space
+	lbSY                    // symbols allowing break after
+	lbWJ                    // word joiner
+	lbZW                    // zero width space
+	lbZWJ                   // zero width joiner
+)
+
+// breakAction describes whether a line may, or must, be broken before a rune.
+type breakAction int
+
+const (
+	noBreak breakAction = iota
+	canBreak
+	mustBreak
+)
+
+// Runes whose line breaking class isn't covered by the general rules in
+// lineBreakClass.
+var breakClasses = map[rune]breakClass{
+	'\t': lbBA, '\n': lbLF, '\v': lbBK, '\f': lbBK, '\r': lbCR, ' ': lbSP,
+	'!': lbEX, '"': lbQU, '$': lbPR, '%': lbPO, '\'': lbQU, '(': lbOP,
+	')': lbCP, '+': lbPR, ',': lbIS, '-': lbHY, '.': lbIS, '/': lbSY,
+	':': lbIS, ';': lbIS, '?': lbEX, '[': lbOP, '\\': lbPR, ']': lbCP,
+	'{': lbOP, '}': lbCL,
+
+	0x0085: lbNL, 0x00A0: lbGL, 0x00A1: lbOP, 0x00A2: lbPO, 0x00AB: lbQU,
+	0x00AD: lbBA, 0x00B0: lbPO, 0x00B1: lbPR, 0x00B4: lbBB, 0x00BB: lbQU,
+	0x00BF: lbOP, 0x02C8: lbBB, 0x02CC: lbBB, 0x02DF: lbBB, 0x034F: lbGL,
+	0x037E: lbIS, 0x0589: lbIS, 0x058A: lbBA, 0x05BE: lbBA, 0x060C: lbIS,
+	0x060D: lbIS, 0x061F: lbEX, 0x066A: lbPO, 0x0F08: lbGL, 0x0F0B: lbBA,
+	0x0F0C: lbGL, 0x0F12: lbGL, 0x1680: lbBA, 0x17D6: lbNS, 0x180E: lbGL,
+
+	0x2007: lbGL, 0x200B: lbZW, 0x200C: lbCM, 0x200D: lbZWJ, 0x2010: lbBA,
+	0x2011: lbGL, 0x2012: lbBA, 0x2013: lbBA, 0x2014: lbB2, 0x2018: lbQU,
+	0x2019: lbQU, 0x201A: lbOP, 0x201B: lbQU, 0x201C: lbQU, 0x201D: lbQU,
+	0x201E: lbOP, 0x201F: lbQU, 0x2024: lbIN, 0x2025: lbIN, 0x2026: lbIN,
+	0x2027: lbBA, 0x2028: lbBK, 0x2029: lbBK, 0x202F: lbGL, 0x2039: lbQU,
+	0x203A: lbQU, 0x203C: lbNS, 0x203D: lbNS, 0x2044: lbIS, 0x2047: lbNS,
+	0x2048: lbNS, 0x2049: lbNS, 0x2060: lbWJ, 0x2103: lbPO, 0x2109: lbPO,
+	0x2116: lbPR, 0x2212: lbPR, 0x2213: lbPR, 0x2E3A: lbB2, 0x2E3B: lbB2,
+
+	// CJK symbols and punctuation
+	0x3000: lbBA, 0x3001: lbCL, 0x3002: lbCL, 0x3005: lbNS, 0x301C: lbNS,
+	0x303B: lbNS, 0x303C: lbNS,
+
+	// Kana
+	0x309B: lbNS, 0x309C: lbNS, 0x309D: lbNS, 0x309E: lbNS, 0x30A0: lbNS,
+	0x30FB: lbNS, 0x30FD: lbNS, 0x30FE: lbNS,
+
+	// Vertical, small and fullwidth forms
+	0xFE10: lbIS, 0xFE11: lbCL, 0xFE12: lbCL, 0xFE13: lbIS, 0xFE14: lbIS,
+	0xFE15: lbEX, 0xFE16: lbEX, 0xFE19: lbIN, 0xFE50: lbCL, 0xFE52: lbCL,
+	0xFE54: lbNS, 0xFE55: lbNS, 0xFE56: lbEX, 0xFE57: lbEX, 0xFE69: lbPR,
+	0xFE6A: lbPO, 0xFEFF: lbWJ, 0xFF01: lbEX, 0xFF04: lbPR, 0xFF05: lbPO,
+	0xFF0C: lbCL, 0xFF0E: lbCL, 0xFF1A: lbNS, 0xFF1B: lbNS, 0xFF1F: lbEX,
+	0xFF61: lbCL, 0xFF64: lbCL, 0xFF65: lbNS, 0xFF9E: lbNS, 0xFF9F: lbNS,
+	0xFFE0: lbPO, 0xFFE1: lbPR, 0xFFE5: lbPR, 0xFFE6: lbPR, 0xFFFC: lbCB,
+}
+
+// Small kana, which are conditional Japanese starters (CJ). Following rule
+// LB1 these are resolved to nonstarters (NS), which is the behavior of
+// strict line breaking.
+var smallKana = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x3041, 0x3049, 2}, {0x3063, 0x3063, 1}, {0x3083, 0x3087, 2},
+		{0x308E, 0x308E, 1}, {0x3095, 0x3096, 1}, {0x30A1, 0x30A9, 2},
+		{0x30C3, 0x30C3, 1}, {0x30E3, 0x30E7, 2}, {0x30EE, 0x30EE, 1},
+		{0x30F5, 0x30F6, 1}, {0x30FC, 0x30FC, 1}, {0x31F0, 0x31FF, 1},
+		{0xFF67, 0xFF70, 1},
+	},
+}
+
+// Ideographs and other characters which allow breaks on both sides, like
+// most wide characters.
+var ideographic = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x2E80, 0x2FFF, 1}, {0x3003, 0x3004, 1}, {0x3006, 0x3007, 1},
+		{0x3012, 0x3013, 1}, {0x3020, 0x3029, 1}, {0x3030, 0x303A, 1},
+		{0x303D, 0x303F, 1}, {0x3040, 0x30FF, 1}, {0x3100, 0x31EF, 1},
+		{0x3200, 0x4DBF, 1}, {0x4E00, 0x9FFF, 1}, {0xA000, 0xA4CF, 1},
+		{0xF900, 0xFAFF, 1}, {0xFE30, 0xFE4F, 1}, {0xFF00, 0xFF60, 1},
+		{0xFFE2, 0xFFE4, 1},
+	},
+	R32: []unicode.Range32{
+		{0x1B000, 0x1B2FF, 1}, {0x1F000, 0x1F0FF, 1}, {0x1F200, 0x1F2FF, 1},
+		{0x1F300, 0x1F64F, 1}, {0x1F680, 0x1F6FF, 1}, {0x1F900, 0x1F9FF, 1},
+		{0x1FA70, 0x1FAFF, 1}, {0x20000, 0x2FFFD, 1}, {0x30000, 0x3FFFD, 1},
+	},
+}
+
+// Emoji which can be modified by a skin tone.
+var emojiBase = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{0x261D, 0x261D, 1}, {0x26F9, 0x26F9, 1}, {0x270A, 0x270D, 1},
+	},
+	R32: []unicode.Range32{
+		{0x1F385, 0x1F385, 1}, {0x1F3C2, 0x1F3C4, 1}, {0x1F3C7, 0x1F3C7, 1},
+		{0x1F3CA, 0x1F3CC, 1}, {0x1F442, 0x1F443, 1}, {0x1F446, 0x1F450, 1},
+		{0x1F466, 0x1F478, 1}, {0x1F47C, 0x1F47C, 1}, {0x1F481, 0x1F483, 1},
+		{0x1F485, 0x1F487, 1}, {0x1F48F, 0x1F48F, 1}, {0x1F491, 0x1F491, 1},
+		{0x1F4AA, 0x1F4AA, 1}, {0x1F574, 0x1F575, 1}, {0x1F57A, 0x1F57A, 1},
+		{0x1F590, 0x1F590, 1}, {0x1F595, 0x1F596, 1}, {0x1F645, 0x1F647, 1},
+		{0x1F64B, 0x1F64F, 1}, {0x1F6A3, 0x1F6A3, 1}, {0x1F6B4, 0x1F6B6, 1},
+		{0x1F6C0, 0x1F6C0, 1}, {0x1F6CC, 0x1F6CC, 1}, {0x1F90C, 0x1F90C, 1},
+		{0x1F90F, 0x1F90F, 1}, {0x1F918, 0x1F91F, 1}, {0x1F926, 0x1F926, 1},
+		{0x1F930, 0x1F939, 1}, {0x1F93C, 0x1F93E, 1}, {0x1F977, 0x1F977, 1},
+		{0x1F9B5, 0x1F9B6, 1}, {0x1F9B8, 0x1F9B9, 1}, {0x1F9BB, 0x1F9BB, 1},
+		{0x1F9CD, 0x1F9CF, 1}, {0x1F9D1, 0x1F9DD, 1},
+	},
+}
+
+// lineBreakClass returns the line breaking class of a rune.
+func lineBreakClass(r rune) breakClass {
+	if c, ok := breakClasses[r]; ok {
+		return c
+	}
+
+	switch {
+	case r >= 0x1100 && r <= 0x115F, r >= 0xA960 && r <= 0xA97C:
+		return lbJL
+	case r >= 0x1160 && r <= 0x11A7, r >= 0xD7B0 && r <= 0xD7C6:
+		return lbJV
+	case r >= 0x11A8 && r <= 0x11FF, r >= 0xD7CB && r <= 0xD7FB:
+		return lbJT
+	case r >= 0xAC00 && r <= 0xD7A3:
+		if (r-0xAC00)%28 == 0 {
+			return lbH2
+		}
+		return lbH3
+	case r >= 0x1F1E6 && r <= 0x1F1FF:
+		return lbRI
+	case r >= 0x1F3FB && r <= 0x1F3FF:
+		return lbEM
+	case r >= 0x2000 && r <= 0x200A:
+		return lbBA // spaces other than figure space
+	case r >= 0x2030 && r <= 0x2037:
+		return lbPO
+	case unicode.Is(smallKana, r):
+		return lbNS
+	case unicode.Is(emojiBase, r):
+		return lbEB
+	case unicode.In(r, unicode.Mn, unicode.Mc, unicode.Me, unicode.Cc):
+		return lbCM
+	case unicode.Is(unicode.Nd, r):
+		return lbNU
+	case unicode.Is(unicode.Ps, r):
+		return lbOP
+	case unicode.Is(unicode.Pe, r):
+		return lbCL
+	case unicode.In(r, unicode.Pi, unicode.Pf):
+		return lbQU
+	case unicode.Is(unicode.Sc, r):
+		return lbPR
+	case unicode.Is(ideographic, r):
+		return lbID
+	}
+
+	return lbAL
+}
+
+// isWide reports whether a rune is an East Asian wide or fullwidth
+// character, which affects rule LB30.
+func isWide(r rune) bool {
+	return r >= 0x2E80 && r <= 0xA4CF || r >= 0xF900 && r <= 0xFAFF ||
+		r >= 0xFE30 && r <= 0xFE6F || r >= 0xFF00 && r <= 0xFF60 ||
+		r >= 0xFFE0 && r <= 0xFFE6
+}
+
+// lineBreaker finds line break opportunities following the rules in UAX #14.
+// Runes are passed in one at a time and for each we return whether a line may
+// be broken before it. The zero value is ready to use.
+type lineBreaker struct {
+	started bool
+	prev    breakClass // class of the previous rune after applying LB9 and LB10
+	before  breakClass // class of the last rune that isn't a space
+	zwj     bool       // whether the previous rune was a zero width joiner
+	ri      int        // number of consecutive regional indicators
+}
+
+// next returns whether a line can be broken between the previous rune and
+// the given one.
+func (lb *lineBreaker) next(r rune) breakAction {
+	c := lineBreakClass(r)
+
+	// LB2: never break at the start of text. LB10: a combining mark at the
+	// start is treated as alphabetic.
+	if !lb.started {
+		lb.started = true
+		if c == lbCM || c == lbZWJ {
+			c = lbAL
+		}
+		lb.update(c)
+		return noBreak
+	}
+
+	prev := lb.prev
+	zwj := lb.zwj
+	lb.zwj = c == lbZWJ
+
+	// LB4 and LB5: always break after hard line breaks.
+	switch prev {
+	case lbBK, lbLF, lbNL:
+		lb.update(resolveCM(c))
+		return mustBreak
+	case lbCR:
+		if c != lbLF {
+			lb.update(resolveCM(c))
+			return mustBreak
+		}
+	}
+
+	// LB6: do not break before hard line breaks. LB7: do not break before
+	// spaces or zero width space.
+	switch c {
+	case lbBK, lbCR, lbLF, lbNL, lbSP, lbZW:
+		lb.update(c)
+		return noBreak
+	}
+
+	// LB8: break before any character following a zero width space, even if
+	// spaces intervene.
+	if lb.before == lbZW {
+		lb.update(resolveCM(c))
+		return canBreak
+	}
+
+	// LB8a: do not break after a zero width joiner.
+	if zwj {
+		lb.update(resolveCM(c))
+		return noBreak
+	}
+
+	// LB9: do not break a combining character sequence; treat it as if it
+	// has the line breaking class of the base character.
+	if c == lbCM || c == lbZWJ {
+		switch prev {
+		case lbSP:
+			// LB10: treat any remaining combining mark as alphabetic.
+			c = lbAL
+		default:
+			return noBreak
+		}
+	}
+
+	action := lb.pair(prev, c, r)
+	lb.update(c)
+	return action
+}
+
+// pair applies the rules for breaking between two runes of the given
+// classes, rules LB11 through LB31.
+func (lb *lineBreaker) pair(prev, c breakClass, r rune) breakAction {
+	before := lb.before
+
+	switch {
+	// LB11: do not break before or after word joiners.
+	case c == lbWJ || prev == lbWJ:
+		return noBreak
+
+	// LB12: do not break after non-breaking characters. LB12a: do not break
+	// before them, except after spaces and hyphens.
+	case prev == lbGL:
+		return noBreak
+	case c == lbGL && prev != lbSP && prev != lbBA && prev != lbHY:
+		return noBreak
+
+	// LB13: do not break before closing punctuation, exclamation marks and
+	// symbols, even after spaces.
+	case c == lbCL || c == lbCP || c == lbEX || c == lbIS || c == lbSY:
+		return noBreak
+
+	// LB14: do not break after opening punctuation, even after spaces.
+	case before == lbOP:
+		return noBreak
+
+	// LB15 through LB17: do not break within certain pairs, even with
+	// intervening spaces.
+	case before == lbQU && c == lbOP,
+		(before == lbCL || before == lbCP) && c == lbNS,
+		before == lbB2 && c == lbB2:
+		return noBreak
+
+	// LB18: break after spaces.
+	case prev == lbSP:
+		return canBreak
+
+	// LB19: do not break before or after quotation marks.
+	case c == lbQU || prev == lbQU:
+		return noBreak
+
+	// LB20: break before and after contingent break opportunities.
+	case c == lbCB || prev == lbCB:
+		return canBreak
+
+	// LB21: do not break before hyphen-minus, other hyphens, small kana and
+	// other nonstarters, or after acute accents.
+	case c == lbBA || c == lbHY || c == lbNS || prev == lbBB:
+		return noBreak
+
+	// LB22: do not break before ellipses.
+	case c == lbIN:
+		return noBreak
+
+	// LB23 through LB25: do not break within numbers, or between letters
+	// and numbers.
+	case prev == lbAL && c == lbNU, prev == lbNU && c == lbAL,
+		prev == lbPR && (c == lbID || c == lbEB || c == lbEM),
+		(prev == lbID || prev == lbEB || prev == lbEM) && c == lbPO,
+		(prev == lbPR || prev == lbPO) && c == lbAL,
+		prev == lbAL && (c == lbPR || c == lbPO),
+		(prev == lbCL || prev == lbCP || prev == lbNU) && (c == lbPO || c == lbPR),
+		(prev == lbPO || prev == lbPR) && (c == lbOP || c == lbNU),
+		(prev == lbHY || prev == lbIS || prev == lbNU || prev == lbSY) && c == lbNU:
+		return noBreak
+
+	// LB26 and LB27: do not break Korean syllable blocks.
+	case prev == lbJL && (c == lbJL || c == lbJV || c == lbH2 || c == lbH3),
+		(prev == lbJV || prev == lbH2) && (c == lbJV || c == lbJT),
+		(prev == lbJT || prev == lbH3) && c == lbJT,
+		isKorean(prev) && c == lbPO, prev == lbPR && isKorean(c):
+		return noBreak
+
+	// LB28: do not break between alphabetics. LB29: do not break between
+	// numeric punctuation and alphabetics.
+	case prev == lbAL && c == lbAL, prev == lbIS && c == lbAL:
+		return noBreak
+
+	// LB30: do not break between letters, numbers or ordinary symbols and
+	// opening or closing parentheses.
+	case (prev == lbAL || prev == lbNU) && c == lbOP && !isWide(r),
+		prev == lbCP && (c == lbAL || c == lbNU):
+		return noBreak
+
+	// LB30a: break between pairs of regional indicators (flags) only.
+	case prev == lbRI && c == lbRI:
+		if lb.ri%2 == 1 {
+			return noBreak
+		}
+		return canBreak
+
+	// LB30b: do not break between an emoji base and an emoji modifier.
+	case prev == lbEB && c == lbEM:
+		return noBreak
+	}
+
+	// LB31: break everywhere else.
+	return canBreak
+}
+
+// update records the class of the previous rune.
+func (lb *lineBreaker) update(c breakClass) {
+	if c == lbRI {
+		lb.ri++
+	} else {
+		lb.ri = 0
+	}
+	if c != lbSP {
+		lb.before = c
+	}
+	lb.prev = c
+}
+
+// resolveCM resolves a combining mark which can't be attached to a base
+// character to the alphabetic class, as described in LB10.
+func resolveCM(c breakClass) breakClass {
+	if c == lbCM || c == lbZWJ {
+		return lbAL
+	}
+	return c
+}
+
+func isKorean(c breakClass) bool {
+	return c == lbJL || c == lbJV || c == lbJT || c == lbH2 || c == lbH3
+}
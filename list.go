@@ -0,0 +1,177 @@
+package lipgloss
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Enumerator returns the marker of the item at the given index, counting
+// from 0, in a List.
+type Enumerator func(i int) string
+
+// Bullet marks items with a bullet.
+func Bullet(int) string {
+	return "•"
+}
+
+// Arabic numbers items 1., 2., 3. and so on.
+func Arabic(i int) string {
+	return strconv.Itoa(i+1) + "."
+}
+
+// Alphabet letters items A., B., C. and so on. After Z. come AA., AB. and so
+// on.
+func Alphabet(i int) string {
+	var b []byte
+	for n := i + 1; n > 0; n = (n - 1) / 26 {
+		b = append([]byte{byte('A' + (n-1)%26)}, b...)
+	}
+	return string(b) + "."
+}
+
+// Roman numbers items in roman numerals: I., II., III. and so on.
+func Roman(i int) string {
+	var (
+		b      strings.Builder
+		n      = i + 1
+		values = []int{1000, 900, 500, 400, 100, 90, 50, 40, 10, 9, 5, 4, 1}
+		digits = []string{"M", "CM", "D", "CD", "C", "XC", "L", "XL", "X", "IX", "V", "IV", "I"}
+	)
+	for k, v := range values {
+		for n >= v {
+			b.WriteString(digits[k])
+			n -= v
+		}
+	}
+	b.WriteString(".")
+	return b.String()
+}
+
+// listItem is an item in a List: either text or a nested list.
+type listItem struct {
+	text    string
+	sublist *List
+}
+
+// List renders items one above another, each marked by an enumerator, like
+// a bullet or a number. Enumerators are right-aligned, so the items line up,
+// and items that span several lines are indented under their first line.
+//
+// Example:
+//
+//     l := lipgloss.NewList("Glossier", "Claire's Boutique").
+//         Sublist(lipgloss.NewList("Nyx", "Mac").Enumerator(lipgloss.Roman)).
+//         Item("Sephora").
+//         Enumerator(lipgloss.Arabic).
+//         EnumeratorStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("8")))
+//
+//     fmt.Println(l.Render())
+//
+type List struct {
+	items      []listItem
+	enumerator Enumerator
+	enumStyle  Style
+	itemStyle  Style
+}
+
+// NewList returns a new bulleted list of the given items.
+func NewList(items ...string) List {
+	return List{}.Items(items...)
+}
+
+// Item adds an item.
+func (l List) Item(str string) List {
+	l.items = append(append([]listItem{}, l.items...), listItem{text: str})
+	return l
+}
+
+// Items adds items.
+func (l List) Items(items ...string) List {
+	for _, str := range items {
+		l = l.Item(str)
+	}
+	return l
+}
+
+// Sublist adds a nested list, which is indented under the item before it.
+// Sublists keep their own enumerator and styles.
+func (l List) Sublist(sub List) List {
+	l.items = append(append([]listItem{}, l.items...), listItem{sublist: &sub})
+	return l
+}
+
+// Enumerator sets the function which marks each item. Bullet, Arabic,
+// Alphabet and Roman are available, and any function with the same signature
+// can be used. The default is Bullet.
+func (l List) Enumerator(e Enumerator) List {
+	l.enumerator = e
+	return l
+}
+
+// EnumeratorStyle sets the style enumerators are rendered with.
+func (l List) EnumeratorStyle(s Style) List {
+	l.enumStyle = s
+	return l
+}
+
+// ItemStyle sets the style items are rendered with. Set a width on it to
+// wrap long items.
+func (l List) ItemStyle(s Style) List {
+	l.itemStyle = s
+	return l
+}
+
+// String implements fmt.Stringer.
+func (l List) String() string {
+	return l.Render()
+}
+
+// Render renders the list.
+func (l List) Render() string {
+	enumerator := l.enumerator
+	if enumerator == nil {
+		enumerator = Bullet
+	}
+
+	// Enumerators are rendered up front so they can be aligned to the widest.
+	var (
+		enums = make([]string, len(l.items))
+		width int
+		n     int
+	)
+	for i, item := range l.items {
+		if item.sublist != nil {
+			continue
+		}
+		enums[i] = l.enumStyle.Render(enumerator(n))
+		width = max(width, Width(enums[i]))
+		n++
+	}
+
+	var (
+		lines  []string
+		indent = strings.Repeat(" ", width+1)
+	)
+	for i, item := range l.items {
+		if item.sublist != nil {
+			if len(item.sublist.items) == 0 {
+				continue
+			}
+			for _, line := range strings.Split(item.sublist.Render(), "\n") {
+				lines = append(lines, indent+line)
+			}
+			continue
+		}
+
+		enum := strings.Repeat(" ", width-Width(enums[i])) + enums[i] + " "
+		for k, line := range strings.Split(l.itemStyle.Render(item.text), "\n") {
+			if k == 0 {
+				lines = append(lines, enum+line)
+			} else {
+				lines = append(lines, indent+line)
+			}
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
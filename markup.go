@@ -0,0 +1,247 @@
+package lipgloss
+
+import "strings"
+
+// Markup parses strings with inline style tags into rich text, which can be
+// rendered with Style.RenderText. Tags are enclosed in square brackets and
+// apply until they're closed:
+//
+//     [bold red]error[/] in [link=https://example.com]file[/]
+//
+// A tag is made up of words separated by spaces:
+//
+//     bold, italic, underline, strikethrough, reverse, blink, faint
+//         Text formatting. Short forms b, i, u, s and dim are also understood.
+//     red, #ff0000, fg=9
+//         Foreground color: one of the sixteen ANSI color names, optionally
+//         prefixed with "bright-", or any color with fg=.
+//     on blue, on #0000ff, bg=4
+//         Background color.
+//     link=https://example.com
+//         Makes the text a hyperlink.
+//     warning
+//         A style registered with the given name.
+//
+// [/] closes the last open tag, and [/name] closes the last tag opened as
+// [name], along with any tags opened after it. Tags are nested, so the text
+// in an inner tag is styled by the outer tags too. Anything in brackets that
+// isn't a valid tag, like "[0]", is left as it is. A backslash escapes an
+// opening bracket or another backslash.
+type Markup struct {
+	styles map[string]Style
+}
+
+// NewMarkup returns a new markup parser without any named styles.
+func NewMarkup() *Markup {
+	return &Markup{styles: make(map[string]Style)}
+}
+
+// Register registers a style under the given name, so that tags can refer to
+// it. Named styles take precedence over the built-in words.
+//
+// Example:
+//
+//     m := lipgloss.NewMarkup().
+//         Register("warning", lipgloss.NewStyle().Foreground(lipgloss.Color("11")))
+//     t := m.Parse("[warning]Disk almost full[/]")
+//
+func (m *Markup) Register(name string, s Style) *Markup {
+	m.styles[name] = s
+	return m
+}
+
+// ParseMarkup parses markup into rich text. Only the built-in words can be
+// used in tags; to refer to named styles use a Markup.
+func ParseMarkup(str string) Text {
+	return NewMarkup().Parse(str)
+}
+
+// EscapeMarkup escapes a string so that it's parsed as plain text, even if it
+// contains square brackets.
+func EscapeMarkup(str string) string {
+	return strings.NewReplacer(`\`, `\\`, `[`, `\[`).Replace(str)
+}
+
+// A markupTag is an open tag, along with the style and link of the text in
+// it, which includes the styling of the tags it's nested in.
+type markupTag struct {
+	name  string
+	style Style
+	link  string
+}
+
+// Parse parses markup into rich text. Tags that are left open are closed at
+// the end of the string.
+func (m *Markup) Parse(str string) Text {
+	var (
+		t     Text
+		text  strings.Builder
+		stack []markupTag
+	)
+
+	current := func() markupTag {
+		if len(stack) == 0 {
+			return markupTag{}
+		}
+		return stack[len(stack)-1]
+	}
+
+	flush := func() {
+		if text.Len() == 0 {
+			return
+		}
+		tag := current()
+		t = append(t, Span{Style: tag.style, Text: text.String(), Link: tag.link})
+		text.Reset()
+	}
+
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+
+		if c == '\\' && i+1 < len(str) && (str[i+1] == '[' || str[i+1] == '\\') {
+			text.WriteByte(str[i+1])
+			i++
+			continue
+		}
+
+		end := strings.IndexByte(str[i:], ']')
+		if c != '[' || end < 0 {
+			text.WriteByte(c)
+			continue
+		}
+		tag := str[i+1 : i+end]
+
+		// Closing tags
+		if strings.HasPrefix(tag, "/") {
+			n := len(stack) - 1
+			if name := tag[1:]; name != "" {
+				for n >= 0 && stack[n].name != name {
+					n--
+				}
+			}
+			if n < 0 {
+				text.WriteByte(c)
+				continue
+			}
+			flush()
+			stack = stack[:n]
+			i += end
+			continue
+		}
+
+		// Opening tags
+		parent := current()
+		open, ok := m.parseTag(tag, parent)
+		if !ok {
+			text.WriteByte(c)
+			continue
+		}
+		flush()
+		stack = append(stack, open)
+		i += end
+	}
+	flush()
+
+	return t
+}
+
+// parseTag parses the contents of an opening tag. The resulting style
+// extends the style of the parent tag. If the tag isn't valid ok is false.
+func (m *Markup) parseTag(tag string, parent markupTag) (t markupTag, ok bool) {
+	if tag == "" || tag[0] == ' ' {
+		return t, false
+	}
+	words := strings.Fields(tag)
+
+	t = markupTag{name: tag, style: parent.style.Copy(), link: parent.link}
+
+	apply := func(s Style) {
+		t.style.init()
+		for k, v := range s.rules {
+			t.style.rules[k] = v
+		}
+	}
+
+	for i := 0; i < len(words); i++ {
+		w := words[i]
+
+		if s, ok := m.styles[w]; ok {
+			apply(s)
+			continue
+		}
+
+		switch {
+		case w == "bold" || w == "b":
+			t.style = t.style.Bold(true)
+		case w == "italic" || w == "i":
+			t.style = t.style.Italic(true)
+		case w == "underline" || w == "u":
+			t.style = t.style.Underline(true)
+		case w == "strikethrough" || w == "s":
+			t.style = t.style.Strikethrough(true)
+		case w == "reverse":
+			t.style = t.style.Reverse(true)
+		case w == "blink":
+			t.style = t.style.Blink(true)
+		case w == "faint" || w == "dim":
+			t.style = t.style.Faint(true)
+		case w == "on" && i+1 < len(words):
+			c, ok := markupColor(words[i+1])
+			if !ok {
+				return t, false
+			}
+			t.style = t.style.Background(c)
+			i++
+		case strings.HasPrefix(w, "fg="):
+			t.style = t.style.Foreground(Color(strings.TrimPrefix(w, "fg=")))
+		case strings.HasPrefix(w, "bg="):
+			t.style = t.style.Background(Color(strings.TrimPrefix(w, "bg=")))
+		case strings.HasPrefix(w, "link="):
+			t.link = strings.TrimPrefix(w, "link=")
+		default:
+			c, ok := markupColor(w)
+			if !ok {
+				return t, false
+			}
+			t.style = t.style.Foreground(c)
+		}
+	}
+
+	return t, true
+}
+
+// The sixteen ANSI colors by name.
+var markupColors = map[string]Color{
+	"black":          "0",
+	"red":            "1",
+	"green":          "2",
+	"yellow":         "3",
+	"blue":           "4",
+	"magenta":        "5",
+	"cyan":           "6",
+	"white":          "7",
+	"bright-black":   "8",
+	"bright-red":     "9",
+	"bright-green":   "10",
+	"bright-yellow":  "11",
+	"bright-blue":    "12",
+	"bright-magenta": "13",
+	"bright-cyan":    "14",
+	"bright-white":   "15",
+}
+
+// markupColor parses a color name or hex color.
+func markupColor(w string) (Color, bool) {
+	if c, ok := markupColors[w]; ok {
+		return c, true
+	}
+	if len(w) == 7 && w[0] == '#' {
+		for _, c := range strings.ToLower(w[1:]) {
+			if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+				return "", false
+			}
+		}
+		return Color(w), true
+	}
+	return "", false
+}
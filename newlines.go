@@ -0,0 +1,39 @@
+package lipgloss
+
+import "strings"
+
+// normalizeNewlines converts "\r\n" line endings to "\n". Other carriage
+// returns are interpreted the way a terminal would: text following one
+// overwrites the line from its start, as often seen in the output of progress
+// indicators.
+func normalizeNewlines(str string) string {
+	if !strings.ContainsRune(str, '\r') {
+		return str
+	}
+
+	str = strings.Replace(str, "\r\n", "\n", -1)
+	if !strings.ContainsRune(str, '\r') {
+		return str
+	}
+
+	lines := strings.Split(str, "\n")
+	for i, l := range lines {
+		if !strings.ContainsRune(l, '\r') {
+			continue
+		}
+
+		parts := strings.Split(l, "\r")
+		l = parts[0]
+		for _, p := range parts[1:] {
+			l = p + truncateLeft(l, stringWidth(p))
+		}
+		lines[i] = l
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// hasCRLF returns whether a string uses "\r\n" line endings.
+func hasCRLF(str string) bool {
+	return strings.Contains(str, "\r\n")
+}
@@ -0,0 +1,78 @@
+package lipgloss
+
+import (
+	"math"
+	"strings"
+)
+
+// Overlay places a block of text on top of another, with its top left corner
+// at the given cell and line of the base. The base shows around the top
+// block, keeping its styles on both sides, and is extended with spaces where
+// the top block reaches beyond it. Parts of the top block at negative
+// coordinates are cut off. Tabs are expanded into spaces according to
+// DefaultTabWidth.
+//
+// Example:
+//
+//     dialog := dialogStyle.Render("Are you sure you want to quit?")
+//     fmt.Println(lipgloss.Overlay(view, dialog, 10, 4))
+//
+func Overlay(base, top string, x, y int) string {
+	// Tabs are expanded first, as they'd be cut at the wrong columns.
+	var (
+		baseLines = carryStyles(strings.Split(expandTabs(normalizeNewlines(base), DefaultTabWidth), "\n"))
+		topLines  = carryStyles(strings.Split(expandTabs(normalizeNewlines(top), DefaultTabWidth), "\n"))
+	)
+
+	if y < 0 {
+		topLines = topLines[min(-y, len(topLines)):]
+		y = 0
+	}
+	if x < 0 {
+		for i, l := range topLines {
+			topLines[i] = truncateLeft(l, -x)
+		}
+		x = 0
+	}
+
+	for len(baseLines) < y+len(topLines) {
+		baseLines = append(baseLines, "")
+	}
+
+	for i, t := range topLines {
+		var (
+			b  = baseLines[y+i]
+			tw = stringWidth(t)
+			bw = stringWidth(b)
+		)
+
+		var l strings.Builder
+		if bw > x {
+			l.WriteString(truncateRight(b, x))
+		} else {
+			l.WriteString(b)
+			l.WriteString(strings.Repeat(" ", x-bw))
+		}
+		l.WriteString(t)
+		if bw > x+tw {
+			l.WriteString(truncateLeft(b, x+tw))
+		}
+		baseLines[y+i] = l.String()
+	}
+
+	return strings.Join(baseLines, "\n")
+}
+
+// OverlayPosition places a block of text on top of another like Overlay, at a
+// position within the base. For example, Center, Center places the top block
+// in the middle of the base, and Right, Bottom in its bottom right corner.
+//
+// Example:
+//
+//     fmt.Println(lipgloss.OverlayPosition(view, dialog, lipgloss.Center, lipgloss.Center))
+//
+func OverlayPosition(base, top string, hPos, vPos Position) string {
+	x := int(math.Round(float64(Width(base)-Width(top)) * hPos.value()))
+	y := int(math.Round(float64(Height(base)-Height(top)) * vPos.value()))
+	return Overlay(base, top, max(0, x), max(0, y))
+}
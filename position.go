@@ -3,8 +3,6 @@ package lipgloss
 import (
 	"math"
 	"strings"
-
-	"github.com/muesli/reflow/ansi"
 )
 
 // Position represents a position along a horizontal or vertical axis. It's in
@@ -41,6 +39,7 @@ func Place(width, height int, hPos, vPos Position, str string, opts ...Whitespac
 // block of a given width. If the given width is shorter than the max width of
 // the string (measured by it's longest line) this will be a noöp.
 func PlaceHorizontal(width int, pos Position, str string, opts ...WhitespaceOption) string {
+	str = expandTabs(str, DefaultTabWidth)
 	lines, contentWidth := getLines(str)
 	gap := width - contentWidth
 
@@ -56,7 +55,7 @@ func PlaceHorizontal(width int, pos Position, str string, opts ...WhitespaceOpti
 	var b strings.Builder
 	for i, l := range lines {
 		// Is this line shorter than the longest line?
-		short := max(0, contentWidth-ansi.PrintableRuneWidth(l))
+		short := max(0, contentWidth-stringWidth(l))
 
 		switch pos {
 		case Left:
@@ -91,6 +90,7 @@ func PlaceHorizontal(width int, pos Position, str string, opts ...WhitespaceOpti
 // of a given height. If the given height is shorter than the height of the
 // string (measured by it's newlines) then this will be a noöp.
 func PlaceVertical(height int, pos Position, str string, opts ...WhitespaceOption) string {
+	str = expandTabs(normalizeNewlines(str), DefaultTabWidth)
 	contentHeight := strings.Count(str, "\n") + 1
 	gap := height - contentHeight
 
@@ -1,5 +1,7 @@
 package lipgloss
 
+import "math"
+
 // This could (should) probably just be moved into NewStyle(). We've broken it
 // out so we can call it in a lazy way.
 func (s *Style) init() {
@@ -17,8 +19,16 @@ func (s *Style) set(key propKey, value interface{}) {
 		// We don't allow negative integers on any of our values, so just keep
 		// them at zero or above. We could use uints instead, but the
 		// conversions are a little tedious so we're sticking with ints for
-		// sake of usability.
+		// sake of usability. The one exception is the tab width, which can be
+		// set to NoTabConversion.
+		if key == tabWidthKey && v < 0 {
+			s.rules[key] = NoTabConversion
+			return
+		}
 		s.rules[key] = max(0, v)
+	case float64:
+		// Same goes for relative sizes.
+		s.rules[key] = math.Max(0, v)
 	default:
 		s.rules[key] = v
 	}
@@ -91,7 +101,10 @@ func (s Style) Background(c TerminalColor) Style {
 }
 
 // Width sets the width of the block before applying margins. The width, if
-// set, also determines where text will wrap.
+// set, also determines where text will wrap. Lines are broken at the
+// opportunities defined by the Unicode line breaking algorithm (UAX #14), so
+// text written without spaces, such as Chinese or Japanese, wraps too. To
+// keep lines from wrapping see WhiteSpace.
 func (s Style) Width(i int) Style {
 	s.set(widthKey, i)
 	return s
@@ -105,12 +118,77 @@ func (s Style) Height(i int) Style {
 	return s
 }
 
+// MinWidth sets the minimum width of the block before applying margins. The
+// block is padded up to this width when it's narrower, but unlike Width it
+// doesn't make text wrap, so the block grows with its content.
+func (s Style) MinWidth(i int) Style {
+	s.set(minWidthKey, i)
+	return s
+}
+
+// MinHeight sets the minimum height of the block before applying margins. It
+// works like Height, which already only grows a block, and the larger of the
+// two is used.
+func (s Style) MinHeight(i int) Style {
+	s.set(minHeightKey, i)
+	return s
+}
+
+// RelativeWidth sets the width of the block, including its border and
+// margins, as a fraction of the available width, so 0.5 is half of it.
+// Relative sizes are resolved by RenderWithin, which overrides Width with the
+// result, and are ignored by Render.
+//
+// Example:
+//
+//     // A sidebar a quarter of the width of the terminal
+//     sidebar := lipgloss.NewStyle().RelativeWidth(0.25).Border(lipgloss.NormalBorder())
+//     fmt.Println(sidebar.RenderWithin(termWidth, termHeight, menu))
+//
+func (s Style) RelativeWidth(f float64) Style {
+	s.set(relativeWidthKey, f)
+	return s
+}
+
+// RelativeHeight sets the height of the block, including its border and
+// margins, as a fraction of the available height. See RelativeWidth.
+func (s Style) RelativeHeight(f float64) Style {
+	s.set(relativeHeightKey, f)
+	return s
+}
+
 // Align sets a text alignment rule.
 func (s Style) Align(p Position) Style {
 	s.set(alignKey, p)
 	return s
 }
 
+// AlignVertical sets a vertical alignment rule, which determines where the
+// block is placed when it's shorter than the height set on the style. The
+// value works like the one passed to Align, with 0 being the top and 1 the
+// bottom. By default blocks are placed at the top.
+//
+// Example:
+//
+//     // Center the text in a box ten cells high
+//     s := lipgloss.NewStyle().Height(10).AlignVertical(lipgloss.Center)
+//
+func (s Style) AlignVertical(p Position) Style {
+	s.set(alignVerticalKey, p)
+	return s
+}
+
+// Justify sets a rule for justifying wrapped text. When set, extra spaces are
+// distributed between the words of every wrapped line so that both edges of
+// the text line up. The last line of each paragraph, and lines without any
+// spaces, are aligned according to the text alignment as usual.
+//
+// Justification requires a width to be set.
+func (s Style) Justify(v bool) Style {
+	s.set(justifyKey, v)
+	return s
+}
+
 // Padding is a shorthand method for setting padding on all sides at once.
 //
 // With one argument, the value is applied to all sides.
@@ -162,6 +240,24 @@ func (s Style) PaddingBottom(i int) Style {
 	return s
 }
 
+// RelativePadding is a shorthand method for setting padding as fractions of
+// the available space: the left and right padding of the width and the top
+// and bottom padding of the height. Arguments work like the ones passed to
+// Padding. Relative padding is resolved by RenderWithin, which overrides the
+// padding on the same sides with the result, and is ignored by Render.
+func (s Style) RelativePadding(f ...float64) Style {
+	top, right, bottom, left, ok := whichSidesFloat(f...)
+	if !ok {
+		return s
+	}
+
+	s.set(relativePaddingTopKey, top)
+	s.set(relativePaddingRightKey, right)
+	s.set(relativePaddingBottomKey, bottom)
+	s.set(relativePaddingLeftKey, left)
+	return s
+}
+
 // ColorWhitespace determines whether or not the background color should be
 // applied to the padding. This is true by default as it's more than likely the
 // desired and expected behavior, but it can be disabled for certain graphic
@@ -222,6 +318,21 @@ func (s Style) MarginBottom(i int) Style {
 	return s
 }
 
+// RelativeMargin is a shorthand method for setting margins as fractions of
+// the available space, like RelativePadding.
+func (s Style) RelativeMargin(f ...float64) Style {
+	top, right, bottom, left, ok := whichSidesFloat(f...)
+	if !ok {
+		return s
+	}
+
+	s.set(relativeMarginTopKey, top)
+	s.set(relativeMarginRightKey, right)
+	s.set(relativeMarginBottomKey, bottom)
+	s.set(relativeMarginLeftKey, left)
+	return s
+}
+
 // MarginBackground sets the background color of the margin. Note that this is
 // also set when inheriting from a style with a background color. In that case
 // the background color on that style will set the margin color on this style.
@@ -430,10 +541,43 @@ func (s Style) BorderLeftBackground(c TerminalColor) Style {
 	return s
 }
 
+// InlineSeparator sets the string newlines are replaced with in inline mode.
+// By default newlines are removed, so that "foo\nbar" becomes "foobar".
+//
+// Example:
+//
+//     // Renders "foo bar"
+//     lipgloss.NewStyle().Inline(true).InlineSeparator(" ").Render("foo\nbar")
+//
+func (s Style) InlineSeparator(sep string) Style {
+	s.set(inlineSeparatorKey, sep)
+	return s
+}
+
+// InlineSpacing determines whether horizontal padding and margins are
+// rendered in inline mode, which is useful for rendering badges and pills
+// within a line of text. Vertical padding and margins, and borders, are never
+// rendered inline.
+//
+// Example:
+//
+//     badge := lipgloss.NewStyle().
+//         Inline(true).
+//         InlineSpacing(true).
+//         Padding(0, 1).
+//         Background(lipgloss.Color("63"))
+//
+func (s Style) InlineSpacing(v bool) Style {
+	s.set(inlineSpacingKey, v)
+	return s
+}
+
 // Inline makes rendering output one line and disables the rendering of
 // margins, padding and borders. This is useful when you need a style to apply
 // only to font rendering and don't want it to change any physical dimensions.
-// It works well with Style.MaxWidth.
+// It works well with Style.MaxWidth. Newlines are removed, unless a separator
+// is set with InlineSeparator, and horizontal padding and margins can be
+// turned back on with InlineSpacing.
 //
 // Because this in intended to be used at the time of render, this method will
 // not mutate the style and instead return a copy.
@@ -497,6 +641,98 @@ func (s Style) StrikethroughSpaces(v bool) Style {
 	return s
 }
 
+// Wrap sets the wrap mode, which determines how text is wrapped when a width
+// is set. By default text wraps at word boundaries and words that are too
+// wide to fit will overflow. To break long words, such as URLs or hashes, at
+// cell boundaries instead use WrapBreakWords.
+//
+// Example:
+//
+//     s := lipgloss.NewStyle().Width(20).Wrap(lipgloss.WrapBreakWords)
+//
+func (s Style) Wrap(m WrapMode) Style {
+	s.set(wrapModeKey, m)
+	return s
+}
+
+// WhiteSpace sets how white space is handled, following the CSS modes of the
+// same names. By default all white space is kept and lines are wrapped when a
+// width is set, which is WhiteSpacePreWrap. WhiteSpacePre keeps lines as they
+// are, which is useful for logs and code, while WhiteSpaceNormal collapses
+// white space so text can be reflowed.
+//
+// Example:
+//
+//     s := lipgloss.NewStyle().Width(40).WhiteSpace(lipgloss.WhiteSpacePre)
+//
+func (s Style) WhiteSpace(w WhiteSpace) Style {
+	s.set(whiteSpaceKey, w)
+	return s
+}
+
+// HangingIndent sets the number of cells lines continuing a wrapped line are
+// indented by. Lines following a newline in the original text are not
+// indented. Like the width, this affects wrapping only.
+func (s Style) HangingIndent(i int) Style {
+	s.set(hangingIndentKey, i)
+	return s
+}
+
+// WrapPrefix sets a string to be placed at the beginning of lines continuing
+// a wrapped line, after the hanging indent, if any. The prefix counts towards
+// the width of the line.
+//
+// Example:
+//
+//     s := lipgloss.NewStyle().Width(40).WrapPrefix("↪ ")
+//
+func (s Style) WrapPrefix(p string) Style {
+	s.set(wrapPrefixKey, p)
+	return s
+}
+
+// TabWidth sets the distance between tab stops. Tabs are expanded into spaces
+// up to the next tab stop before text is wrapped and measured. A tab width of
+// 0 removes tabs altogether, and NoTabConversion leaves them untouched. By
+// default DefaultTabWidth is used.
+//
+// Example:
+//
+//     s := lipgloss.NewStyle().TabWidth(4)
+//
+func (s Style) TabWidth(n int) Style {
+	s.set(tabWidthKey, n)
+	return s
+}
+
+// KeepLineEndings determines whether to keep \r\n line endings. Line endings
+// are always normalized to \n before rendering so that text can be measured
+// correctly. When this is set and the text passed to Render uses \r\n line
+// endings, the rendered output uses them too.
+func (s Style) KeepLineEndings(v bool) Style {
+	s.set(keepLineEndingsKey, v)
+	return s
+}
+
+// Direction sets the direction of text. Bidirectional text, such as Arabic or
+// Hebrew mixed with English, is reordered for display following the Unicode
+// Bidirectional Algorithm, and lines are aligned from right to left or the
+// other way around. In right-to-left blocks the meaning of left and right
+// flips: left padding, left alignment and the left border go on the right,
+// at the start of the text, and vice versa.
+//
+// When no direction is set text is left as it is, which is what you want for
+// terminals that reorder bidirectional text on their own.
+//
+// Example:
+//
+//     s := lipgloss.NewStyle().Direction(lipgloss.DirectionAuto)
+//
+func (s Style) Direction(d Direction) Style {
+	s.set(directionKey, d)
+	return s
+}
+
 // whichSidesInt is a helper method for setting values on sides of a block based
 // on the number of arguments. It follows the CSS shorthand rules for blocks
 // like margin, padding. and borders. Here are how the rules work:
@@ -537,6 +773,39 @@ func whichSidesInt(i ...int) (top, right, bottom, left int, ok bool) {
 	return top, right, bottom, left, ok
 }
 
+// whichSidesFloat is like whichSidesInt, except it operates on a series of
+// float values. See the comment on whichSidesInt for details on how this
+// works.
+func whichSidesFloat(f ...float64) (top, right, bottom, left float64, ok bool) {
+	switch len(f) {
+	case 1:
+		top = f[0]
+		bottom = f[0]
+		left = f[0]
+		right = f[0]
+		ok = true
+	case 2:
+		top = f[0]
+		bottom = f[0]
+		left = f[1]
+		right = f[1]
+		ok = true
+	case 3:
+		top = f[0]
+		left = f[1]
+		right = f[1]
+		bottom = f[2]
+		ok = true
+	case 4:
+		top = f[0]
+		right = f[1]
+		bottom = f[2]
+		left = f[3]
+		ok = true
+	}
+	return top, right, bottom, left, ok
+}
+
 // whichSidesBool is like whichSidesInt, except it operates on a series of
 // boolean values. See the comment on whichSidesInt for details on how this
 // works.
@@ -1,20 +1,21 @@
 package lipgloss
 
-import (
-	"strings"
-
-	"github.com/muesli/reflow/ansi"
-)
+import "strings"
 
 // Width returns the cell width of characters in the string. ANSI sequences are
 // ignored and characters wider than one cell (such as Chinese characters) are
-// appropriately measured.
+// appropriately measured. Characters made up of several code points, like
+// emoji sequences, flags and letters with combining marks, are measured as a
+// whole.
 //
 // You should use this instead of len(string) len([]rune(string) as neither
 // will give you accurate results.
+//
+// Tabs are measured as if they were expanded to the next tab stop, according
+// to DefaultTabWidth.
 func Width(str string) (width int) {
-	for _, l := range strings.Split(str, "\n") {
-		w := ansi.PrintableRuneWidth(l)
+	for _, l := range strings.Split(expandTabs(normalizeNewlines(str), DefaultTabWidth), "\n") {
+		w := stringWidth(l)
 		if w > width {
 			width = w
 		}
@@ -23,10 +24,9 @@ func Width(str string) (width int) {
 	return width
 }
 
-// Height returns height of a string in cells. This is done simply by
-// counting \n characters. If your strings use \r\n for newlines you should
-// convert them to \n first, or simply write a separate function for measuring
-// height.
+// Height returns height of a string in cells. This is done by counting \n
+// characters. Both \n and \r\n line endings are supported, while a bare \r
+// returns to the start of the same line, as it would in a terminal.
 func Height(str string) int {
-	return strings.Count(str, "\n") + 1
+	return strings.Count(normalizeNewlines(str), "\n") + 1
 }
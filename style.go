@@ -1,11 +1,10 @@
 package lipgloss
 
 import (
+	"math"
 	"strings"
 	"unicode"
 
-	"github.com/muesli/reflow/truncate"
-	"github.com/muesli/reflow/wordwrap"
 	"github.com/muesli/termenv"
 )
 
@@ -25,7 +24,10 @@ const (
 	backgroundKey
 	widthKey
 	heightKey
+	minWidthKey
+	minHeightKey
 	alignKey
+	alignVerticalKey
 
 	// Padding.
 	paddingTopKey
@@ -64,10 +66,34 @@ const (
 	borderLeftBackgroundKey
 
 	inlineKey
+	inlineSeparatorKey
+	inlineSpacingKey
 	maxWidthKey
 	maxHeightKey
 	underlineSpacesKey
 	strikethroughSpacesKey
+	justifyKey
+	tabWidthKey
+	keepLineEndingsKey
+	directionKey
+	whiteSpaceKey
+
+	// Wrapping.
+	wrapModeKey
+	hangingIndentKey
+	wrapPrefixKey
+
+	// Relative sizes, resolved by RenderWithin.
+	relativeWidthKey
+	relativeHeightKey
+	relativePaddingTopKey
+	relativePaddingRightKey
+	relativePaddingBottomKey
+	relativePaddingLeftKey
+	relativeMarginTopKey
+	relativeMarginRightKey
+	relativeMarginBottomKey
+	relativeMarginLeftKey
 )
 
 // A set of properties.
@@ -130,6 +156,10 @@ func (s Style) Inherit(i Style) Style {
 		case paddingTopKey, paddingRightKey, paddingBottomKey, paddingLeftKey:
 			// Padding is not inherited
 			continue
+		case relativeMarginTopKey, relativeMarginRightKey, relativeMarginBottomKey, relativeMarginLeftKey,
+			relativePaddingTopKey, relativePaddingRightKey, relativePaddingBottomKey, relativePaddingLeftKey:
+			// Nor are relative margins and padding
+			continue
 		case backgroundKey:
 			s.rules[k] = v
 
@@ -147,7 +177,10 @@ func (s Style) Inherit(i Style) Style {
 	return s
 }
 
-// Render applies the defined style formatting to a given string.
+// Render applies the defined style formatting to a given string. Strings
+// which are already partly styled, for instance with another style, keep
+// their styling: the style is re-applied after every reset in the string, so
+// that nested styles compose.
 func (s Style) Render(str string) string {
 	var (
 		te           termenv.Style
@@ -168,6 +201,17 @@ func (s Style) Render(str string) string {
 		width  = s.getAsInt(widthKey)
 		height = s.getAsInt(heightKey)
 		align  = s.getAsPosition(alignKey)
+		valign = s.getAsPosition(alignVerticalKey)
+
+		minWidth  = s.getAsInt(minWidthKey)
+		minHeight = s.getAsInt(minHeightKey)
+
+		justify = s.getAsBool(justifyKey, false)
+
+		keepLineEndings = s.getAsBool(keepLineEndingsKey, false) && hasCRLF(str)
+
+		bidi = s.isSet(directionKey)
+		dir  = s.getAsDirection(directionKey)
 
 		topPadding    = s.getAsInt(paddingTopKey)
 		rightPadding  = s.getAsInt(paddingRightKey)
@@ -176,9 +220,16 @@ func (s Style) Render(str string) string {
 
 		colorWhitespace = s.getAsBool(colorWhitespaceKey, true)
 		inline          = s.getAsBool(inlineKey, false)
+		inlineSeparator = s.getAsString(inlineSeparatorKey)
+		inlineSpacing   = s.getAsBool(inlineSpacingKey, false)
 		maxWidth        = s.getAsInt(maxWidthKey)
 		maxHeight       = s.getAsInt(maxHeightKey)
 
+		whiteSpace    = s.getAsWhiteSpace(whiteSpaceKey)
+		wrapMode      = s.getAsWrapMode(wrapModeKey)
+		hangingIndent = s.getAsInt(hangingIndentKey)
+		wrapPrefix    = s.getAsString(wrapPrefixKey)
+
 		underlineSpaces     = underline && s.getAsBool(underlineSpacesKey, true)
 		strikethroughSpaces = strikethrough && s.getAsBool(strikethroughSpacesKey, true)
 
@@ -252,33 +303,119 @@ func (s Style) Render(str string) string {
 		teSpace = teSpace.CrossOut()
 	}
 
+	// Normalize line endings
+	str = normalizeNewlines(str)
+
+	// Collapse white space
+	if whiteSpace.collapses() {
+		str = collapseWhiteSpace(str)
+	}
+
 	// Strip newlines in single line mode
 	if inline {
-		str = strings.Replace(str, "\n", "", -1)
+		str = strings.Replace(str, "\n", inlineSeparator, -1)
+	}
+
+	// Expand tabs so they can be measured
+	tabWidth := DefaultTabWidth
+	if s.isSet(tabWidthKey) {
+		tabWidth = s.getAsInt(tabWidthKey)
+	}
+	str = expandTabs(str, tabWidth)
+
+	// In right-to-left blocks left and right trade places
+	rtl := bidi && isRTL(str, dir)
+	if rtl {
+		leftPadding, rightPadding = rightPadding, leftPadding
+		align = Right - align
 	}
 
 	// Word wrap
-	if !inline && width > 0 {
-		str = wordwrap.String(str, width-leftPadding-rightPadding)
+	var (
+		wrapAt  = width - leftPadding - rightPadding
+		wrapped []bool // which lines were broken by word wrapping
+		indents []int  // widths of the hanging indent and prefix of each line
+		lineRTL []bool // which lines are in right-to-left paragraphs
+		lines   = strings.Split(str, "\n")
+	)
+	if !inline && width > 0 && whiteSpace.wraps() {
+		lines, wrapped, indents = wrapper{
+			width:  wrapAt,
+			mode:   wrapMode,
+			indent: hangingIndent,
+			prefix: wrapPrefix,
+		}.lines(str)
 	}
 
+	// Reorder bidirectional text for display
+	if bidi {
+		lines, lineRTL = bidiReorder(lines, wrapped, dir)
+	}
+
+	// Close styles in pre-styled text at the end of each line and reopen
+	// them on the next, so they don't spill into padding and borders
+	lines = carryStyles(lines)
+	str = strings.Join(lines, "\n")
+
 	// Render core text
 	{
 		var b strings.Builder
 
+		render := func(str string) string {
+			if !useSpaceStyler {
+				// Re-apply the style after any resets in pre-styled text
+				return te.Styled(reapplyStyle(str, styleStart(te)))
+			}
+
+			// Look for spaces and apply a different styler. Styles in the
+			// text itself are kept on top of ours.
+			var (
+				b     strings.Builder
+				inner strings.Builder // sequences since the last reset
+			)
+			for r := newClusterReader(str); r.next(); {
+				switch {
+				case isSGRReset(r.cluster):
+					inner.Reset()
+					continue
+				case isSGR(r.cluster):
+					inner.WriteString(r.cluster)
+					continue
+				case r.seq:
+					b.WriteString(r.cluster)
+					continue
+				}
+				st := te
+				if unicode.IsSpace([]rune(r.cluster)[0]) {
+					st = teSpace
+				}
+				b.WriteString(st.Styled(inner.String() + r.cluster))
+			}
+			return b.String()
+		}
+
 		l := strings.Split(str, "\n")
 		for i := range l {
-			if useSpaceStyler {
-				// Look for spaces and apply a different styler
-				for _, r := range l[i] {
-					if unicode.IsSpace(r) {
-						b.WriteString(teSpace.Styled(string(r)))
-						continue
-					}
-					b.WriteString(te.Styled(string(r)))
+			if justify && i < len(wrapped) && wrapped[i] {
+				var st *termenv.Style
+				if colorWhitespace || styleWhitespace {
+					st = &teWhitespace
+				}
+
+				// Skip the hanging indent and prefix on continuation lines,
+				// which end up on the right in right-to-left paragraphs.
+				var lead, trail int
+				if i < len(indents) {
+					lead = indents[i]
+				}
+				if i < len(lineRTL) && lineRTL[i] {
+					lead, trail = 0, lead
 				}
+
+				extra := wrapAt - stringWidth(l[i])
+				b.WriteString(justifyLine(l[i], extra, lead, trail, render, st))
 			} else {
-				b.WriteString(te.Styled(l[i]))
+				b.WriteString(render(l[i]))
 			}
 			if i != len(l)-1 {
 				b.WriteRune('\n')
@@ -289,7 +426,7 @@ func (s Style) Render(str string) string {
 	}
 
 	// Padding
-	if !inline {
+	if !inline || inlineSpacing {
 		if leftPadding > 0 {
 			var st *termenv.Style
 			if colorWhitespace || styleWhitespace {
@@ -305,7 +442,8 @@ func (s Style) Render(str string) string {
 			}
 			str = padRight(str, rightPadding, st)
 		}
-
+	}
+	if !inline {
 		if topPadding > 0 {
 			str = strings.Repeat("\n", topPadding) + str
 		}
@@ -316,11 +454,8 @@ func (s Style) Render(str string) string {
 	}
 
 	// Height
-	if height > 0 {
-		h := strings.Count(str, "\n") + 1
-		if height > h {
-			str += strings.Repeat("\n", height-h)
-		}
+	if h := max(height, minHeight); h > 0 {
+		str = alignTextVertical(str, valign, h)
 	}
 
 	// Set alignment. This will also pad short lines with spaces so that all
@@ -328,18 +463,21 @@ func (s Style) Render(str string) string {
 	// beyond alignment.
 	{
 		numLines := strings.Count(str, "\n")
+		w := max(width, minWidth)
 
-		if !(numLines == 0 && width == 0) {
+		if !(numLines == 0 && w == 0) {
 			var st *termenv.Style
 			if colorWhitespace || styleWhitespace {
 				st = &teWhitespace
 			}
-			str = alignText(str, align, width, st)
+			str = alignText(str, align, w, st)
 		}
 	}
 
 	if !inline {
-		str = s.applyBorder(str)
+		str = s.applyBorder(str, rtl)
+	}
+	if !inline || inlineSpacing {
 		str = s.applyMargins(str, inline)
 	}
 
@@ -348,7 +486,7 @@ func (s Style) Render(str string) string {
 		lines := strings.Split(str, "\n")
 
 		for i := range lines {
-			lines[i] = truncate.String(lines[i], uint(maxWidth))
+			lines[i] = truncateRight(lines[i], maxWidth)
 		}
 
 		str = strings.Join(lines, "\n")
@@ -360,9 +498,61 @@ func (s Style) Render(str string) string {
 		str = strings.Join(lines[:min(maxHeight, len(lines))], "\n")
 	}
 
+	// Restore the original line endings
+	if keepLineEndings {
+		str = strings.Replace(str, "\n", "\r\n", -1)
+	}
+
 	return str
 }
 
+// RenderWithin renders a string like Render, first resolving relative sizes,
+// set with RelativeWidth, RelativeHeight, RelativePadding and RelativeMargin,
+// against the available width and height. Pass the size of the terminal, or
+// of the area the block is placed in, so that layouts adapt when it changes.
+//
+// Example:
+//
+//     s := lipgloss.NewStyle().RelativeWidth(0.5).RelativePadding(0, 0.05)
+//     fmt.Println(s.RenderWithin(termWidth, termHeight, str))
+//
+func (s Style) RenderWithin(width, height int, str string) string {
+	return s.resolveRelative(width, height).Render(str)
+}
+
+// resolveRelative returns a copy of the style with relative sizes replaced by
+// absolute ones for the given available width and height.
+func (s Style) resolveRelative(width, height int) Style {
+	s = s.Copy()
+
+	resolve := func(from, to propKey, avail int) {
+		if s.isSet(from) {
+			s.set(to, int(math.Floor(s.getAsFloat(from)*float64(avail)+0.5)))
+		}
+	}
+	resolve(relativeMarginTopKey, marginTopKey, height)
+	resolve(relativeMarginRightKey, marginRightKey, width)
+	resolve(relativeMarginBottomKey, marginBottomKey, height)
+	resolve(relativeMarginLeftKey, marginLeftKey, width)
+	resolve(relativePaddingTopKey, paddingTopKey, height)
+	resolve(relativePaddingRightKey, paddingRightKey, width)
+	resolve(relativePaddingBottomKey, paddingBottomKey, height)
+	resolve(relativePaddingLeftKey, paddingLeftKey, width)
+
+	// The width and height set on a style don't include the border and
+	// margins, so those are taken off.
+	resolve(relativeWidthKey, widthKey, width)
+	resolve(relativeHeightKey, heightKey, height)
+	if s.isSet(relativeWidthKey) {
+		s.set(widthKey, max(1, s.getAsInt(widthKey)-s.horizontalFrameSize()))
+	}
+	if s.isSet(relativeHeightKey) {
+		s.set(heightKey, max(1, s.getAsInt(heightKey)-s.verticalFrameSize()))
+	}
+
+	return s
+}
+
 func (s Style) applyMargins(str string, inline bool) string {
 	var (
 		topMargin    = s.getAsInt(marginTopKey)
@@ -448,6 +638,38 @@ func padRight(str string, n int, style *termenv.Style) string {
 	return b.String()
 }
 
+// styleStart returns the sequence which starts text styled with a termenv
+// style.
+func styleStart(te termenv.Style) string {
+	return strings.TrimSuffix(te.Styled(""), termenv.CSI+termenv.ResetSeq+"m")
+}
+
+// reapplyStyle inserts the given start sequence after every reset in a
+// string, so that a style carries on after pre-styled portions of text
+// instead of being cancelled by them. Resets which go on to set other
+// attributes are split so that those attributes still take precedence.
+func reapplyStyle(str, start string) string {
+	if start == "" || !strings.Contains(str, termenv.CSI) {
+		return str
+	}
+
+	var b strings.Builder
+	for r := newClusterReader(str); r.next(); {
+		switch {
+		case isSGRReset(r.cluster):
+			b.WriteString(r.cluster)
+			b.WriteString(start)
+		case isSGR(r.cluster) && strings.HasPrefix(r.cluster, termenv.CSI+"0;"):
+			b.WriteString(termenv.CSI + "0m")
+			b.WriteString(start)
+			b.WriteString(termenv.CSI + strings.TrimPrefix(r.cluster, termenv.CSI+"0;"))
+		default:
+			b.WriteString(r.cluster)
+		}
+	}
+	return b.String()
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -0,0 +1,450 @@
+package lipgloss
+
+import "strings"
+
+// HeaderRow is the row index of the header, as passed to the style function
+// of a Table.
+const HeaderRow = -1
+
+// Table renders rows of cells in columns, with borders between them. Column
+// widths are worked out from the content, and cells wrap when a column is
+// too narrow for them.
+//
+// Example:
+//
+//     t := lipgloss.NewTable().
+//         Border(lipgloss.RoundedBorder()).
+//         Headers("Name", "Language", "Stars").
+//         Row("Lip Gloss", "Go", "4000").
+//         Row("Glamour", "Go", "1500").
+//         Align(2, lipgloss.Right).
+//         StyleFunc(func(row, col int) lipgloss.Style {
+//             if row == lipgloss.HeaderRow {
+//                 return lipgloss.NewStyle().Bold(true).Padding(0, 1)
+//             }
+//             return lipgloss.NewStyle().Padding(0, 1)
+//         })
+//
+//     fmt.Println(t.Render())
+//
+type Table struct {
+	headers []string
+	rows    [][]string
+
+	border      Border
+	borderStyle Style
+	styleFunc   func(row, col int) Style
+
+	columns map[int]tableColumn
+	width   int
+
+	borderTop    bool
+	borderBottom bool
+	borderLeft   bool
+	borderRight  bool
+	borderHeader bool
+	borderColumn bool
+	borderRow    bool
+}
+
+// tableColumn holds the settings of a column.
+type tableColumn struct {
+	align    Position
+	hasAlign bool
+	minWidth int
+	maxWidth int // 0 for no maximum
+}
+
+// NewTable returns a new table with a normal border around it and between
+// the header and the rows and the columns.
+func NewTable() Table {
+	return Table{
+		border:       normalBorder,
+		borderTop:    true,
+		borderBottom: true,
+		borderLeft:   true,
+		borderRight:  true,
+		borderHeader: true,
+		borderColumn: true,
+	}
+}
+
+// Headers sets the column headers.
+func (t Table) Headers(headers ...string) Table {
+	t.headers = headers
+	return t
+}
+
+// Row adds a row of cells.
+func (t Table) Row(cells ...string) Table {
+	t.rows = append(append([][]string{}, t.rows...), cells)
+	return t
+}
+
+// Rows adds rows of cells.
+func (t Table) Rows(rows ...[]string) Table {
+	t.rows = append(append([][]string{}, t.rows...), rows...)
+	return t
+}
+
+// Border sets the border. Its junctions, like MiddleTop and Middle, are used
+// where inner rules meet.
+func (t Table) Border(b Border) Table {
+	t.border = b
+	return t
+}
+
+// BorderStyle sets the style the border is rendered with, such as its
+// foreground color.
+func (t Table) BorderStyle(s Style) Table {
+	t.borderStyle = s
+	return t
+}
+
+// BorderTop sets whether to render a rule above the table.
+func (t Table) BorderTop(v bool) Table {
+	t.borderTop = v
+	return t
+}
+
+// BorderBottom sets whether to render a rule below the table.
+func (t Table) BorderBottom(v bool) Table {
+	t.borderBottom = v
+	return t
+}
+
+// BorderLeft sets whether to render a rule to the left of the table.
+func (t Table) BorderLeft(v bool) Table {
+	t.borderLeft = v
+	return t
+}
+
+// BorderRight sets whether to render a rule to the right of the table.
+func (t Table) BorderRight(v bool) Table {
+	t.borderRight = v
+	return t
+}
+
+// BorderHeader sets whether to render a rule between the header and the rows.
+func (t Table) BorderHeader(v bool) Table {
+	t.borderHeader = v
+	return t
+}
+
+// BorderColumn sets whether to render rules between columns.
+func (t Table) BorderColumn(v bool) Table {
+	t.borderColumn = v
+	return t
+}
+
+// BorderRow sets whether to render rules between rows. They're off by
+// default.
+func (t Table) BorderRow(v bool) Table {
+	t.borderRow = v
+	return t
+}
+
+// StyleFunc sets a function which returns the style of each cell. The header
+// is passed as HeaderRow and the rows are counted from 0. Styles set on
+// cells, like padding, count towards the width of the columns.
+func (t Table) StyleFunc(f func(row, col int) Style) Table {
+	t.styleFunc = f
+	return t
+}
+
+// Align sets the horizontal alignment of a column, unless the style of a cell
+// sets its own.
+func (t Table) Align(col int, p Position) Table {
+	return t.column(col, func(c *tableColumn) {
+		c.align = p
+		c.hasAlign = true
+	})
+}
+
+// MinWidth sets the minimum width of a column.
+func (t Table) MinWidth(col, n int) Table {
+	return t.column(col, func(c *tableColumn) {
+		c.minWidth = max(0, n)
+	})
+}
+
+// MaxWidth sets the maximum width of a column. Cells wider than this wrap. A
+// width of 0 removes the maximum.
+func (t Table) MaxWidth(col, n int) Table {
+	return t.column(col, func(c *tableColumn) {
+		c.maxWidth = max(0, n)
+	})
+}
+
+// column updates the settings of a column. The settings are copied so that
+// other tables aren't affected.
+func (t Table) column(col int, update func(*tableColumn)) Table {
+	columns := make(map[int]tableColumn, len(t.columns)+1)
+	for k, v := range t.columns {
+		columns[k] = v
+	}
+	c := columns[col]
+	update(&c)
+	columns[col] = c
+	t.columns = columns
+	return t
+}
+
+// Width sets the total width of the table, including borders. Columns shrink
+// to fit, wrapping their cells, or grow to fill it, within their minimum and
+// maximum widths. By default the table is as wide as its content.
+func (t Table) Width(n int) Table {
+	t.width = max(0, n)
+	return t
+}
+
+// String implements fmt.Stringer.
+func (t Table) String() string {
+	return t.Render()
+}
+
+// Render renders the table.
+func (t Table) Render() string {
+	cols := len(t.headers)
+	for _, r := range t.rows {
+		cols = max(cols, len(r))
+	}
+	if cols == 0 {
+		return ""
+	}
+
+	var (
+		left, right, sep int // widths of the vertical rules
+		b                = t.border
+	)
+	if t.borderLeft {
+		left = stringWidth(b.Left)
+	}
+	if t.borderRight {
+		right = stringWidth(b.Right)
+	}
+	if t.borderColumn {
+		sep = stringWidth(b.Left)
+	}
+
+	widths := t.columnWidths(cols, left+right+sep*(cols-1))
+
+	var lines []string
+	rule := func(l, line, junction, r string) {
+		var s strings.Builder
+		if t.borderLeft {
+			s.WriteString(borderJunction(l, line, left))
+		}
+		for i, w := range widths {
+			if i > 0 && t.borderColumn {
+				s.WriteString(borderJunction(junction, line, sep))
+			}
+			s.WriteString(renderHorizontalEdge("", line, "", w))
+		}
+		if t.borderRight {
+			s.WriteString(borderJunction(r, line, right))
+		}
+		lines = append(lines, t.renderBorder(s.String()))
+	}
+
+	if t.borderTop {
+		rule(b.TopLeft, b.Top, b.MiddleTop, b.TopRight)
+	}
+	if len(t.headers) > 0 {
+		lines = append(lines, t.renderRow(HeaderRow, t.headers, widths)...)
+		if t.borderHeader && len(t.rows) > 0 {
+			rule(b.MiddleLeft, b.Top, b.Middle, b.MiddleRight)
+		}
+	}
+	for i, r := range t.rows {
+		if i > 0 && t.borderRow {
+			rule(b.MiddleLeft, b.Top, b.Middle, b.MiddleRight)
+		}
+		lines = append(lines, t.renderRow(i, r, widths)...)
+	}
+	if t.borderBottom {
+		rule(b.BottomLeft, b.Bottom, b.MiddleBottom, b.BottomRight)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// columnWidths works out the width of each column. Columns start out as wide
+// as their widest cell and, if a total width is set, shrink or grow in
+// proportion to that to fit it. Columns which hit their minimum or maximum
+// width are fixed there and the rest shared among the others.
+func (t Table) columnWidths(cols, borders int) []int {
+	var (
+		bases  = make([]int, cols)
+		floors = make([]int, cols)
+		items  = make([]FlexItem, cols)
+	)
+	for c := range bases {
+		bases[c] = t.cellWidth(HeaderRow, c, t.headers)
+		floors[c] = t.cellMinWidth(HeaderRow, c, t.headers)
+		for i, r := range t.rows {
+			bases[c] = max(bases[c], t.cellWidth(i, c, r))
+			floors[c] = max(floors[c], t.cellMinWidth(i, c, r))
+		}
+		bases[c] = t.clampWidth(c, bases[c])
+		floors[c] = t.clampWidth(c, floors[c])
+		items[c] = FlexItem{grow: 1, shrink: 1}
+	}
+	if t.width == 0 {
+		return bases
+	}
+
+	// Columns don't shrink below their widest word, so the shortfall is
+	// taken from columns that can still wrap. When even that doesn't fit,
+	// words are broken up instead.
+	avail := t.width - borders
+	var need int
+	for _, f := range floors {
+		need += f
+	}
+	if need > avail {
+		for c := range floors {
+			floors[c] = t.clampWidth(c, 0)
+		}
+	}
+
+	for {
+		widths := flexSizes(items, bases, avail)
+
+		fixed := false
+		for c, w := range widths {
+			if cw := max(floors[c], t.clampWidth(c, w)); cw != w {
+				bases[c] = cw
+				items[c] = FlexItem{}
+				fixed = true
+			}
+		}
+		if !fixed {
+			return widths
+		}
+	}
+}
+
+// clampWidth limits a width to the minimum and maximum width of a column.
+func (t Table) clampWidth(col, w int) int {
+	c := t.columns[col]
+	if c.maxWidth > 0 && w > c.maxWidth {
+		w = c.maxWidth
+	}
+	if w < c.minWidth {
+		w = c.minWidth
+	}
+	return w
+}
+
+// cellStyle returns the style of a cell.
+func (t Table) cellStyle(row, col int) Style {
+	s := NewStyle()
+	if t.styleFunc != nil {
+		s = t.styleFunc(row, col)
+	}
+	if c := t.columns[col]; c.hasAlign && !s.isSet(alignKey) {
+		s = s.Copy().Align(c.align)
+	}
+	return s
+}
+
+// cellWidth returns the natural width of a cell.
+func (t Table) cellWidth(row, col int, cells []string) int {
+	if col >= len(cells) {
+		return 0
+	}
+	return Width(renderAtSize(t.cellStyle(row, col), cells[col])(0, 0))
+}
+
+// cellMinWidth returns the width a cell needs to wrap without breaking up
+// words: that of its widest word, with the padding and frame of its style.
+func (t Table) cellMinWidth(row, col int, cells []string) int {
+	if col >= len(cells) {
+		return 0
+	}
+	s := t.cellStyle(row, col)
+	if !s.getAsWhiteSpace(whiteSpaceKey).wraps() {
+		return t.cellWidth(row, col, cells)
+	}
+
+	tabWidth := DefaultTabWidth
+	if s.isSet(tabWidthKey) {
+		tabWidth = s.getAsInt(tabWidthKey)
+	}
+
+	var w int
+	for _, line := range strings.Split(expandTabs(normalizeNewlines(cells[col]), tabWidth), "\n") {
+		for _, seg := range segments(line) {
+			text, shy := trimSoftHyphens(seg.text)
+			if shy {
+				text += "-"
+			}
+			w = max(w, stringWidth(text))
+		}
+	}
+	return w + s.getAsInt(paddingLeftKey) + s.getAsInt(paddingRightKey) + s.horizontalFrameSize()
+}
+
+// renderRow renders a row of cells, which is as tall as its tallest cell.
+func (t Table) renderRow(row int, cells []string, widths []int) []string {
+	var (
+		blocks = make([]string, len(widths))
+		height int
+	)
+	for c, w := range widths {
+		var str string
+		if c < len(cells) {
+			str = cells[c]
+		}
+		// Cells too narrow for their widest word break it up rather than
+		// have it cut off, unless their style sets a wrap mode.
+		s := t.cellStyle(row, c)
+		if !s.isSet(wrapModeKey) {
+			s = s.Copy().Wrap(WrapBreakWords)
+		}
+		blocks[c] = renderAtSize(s, str)(w, 0)
+		height = max(height, Height(blocks[c]))
+	}
+
+	var (
+		lines = make([]string, height)
+		sep   = t.renderBorder(t.border.Left)
+	)
+	for c, block := range blocks {
+		for i, l := range strings.Split(fitBlock(block, widths[c], height, t.cellStyle(row, c).getAsPosition(alignKey), Top), "\n") {
+			if c > 0 && t.borderColumn {
+				lines[i] += sep
+			}
+			lines[i] += l
+		}
+	}
+	for i := range lines {
+		if t.borderLeft {
+			lines[i] = t.renderBorder(t.border.Left) + lines[i]
+		}
+		if t.borderRight {
+			lines[i] += t.renderBorder(t.border.Right)
+		}
+	}
+
+	return lines
+}
+
+// borderJunction returns a corner or junction of the border, or the line of
+// the rule in its place if the border doesn't have one that's as wide as the
+// vertical rules, as with borders made before junctions were added.
+func borderJunction(glyph, line string, width int) string {
+	if glyph != "" && stringWidth(glyph) == width {
+		return glyph
+	}
+	return renderHorizontalEdge("", line, "", width)
+}
+
+// renderBorder renders part of the border with the border style.
+func (t Table) renderBorder(str string) string {
+	if str == "" {
+		return str
+	}
+	return t.borderStyle.Inline(true).Render(str)
+}
@@ -0,0 +1,84 @@
+package lipgloss
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTableKeepsCellText(t *testing.T) {
+	tt := []struct {
+		name  string
+		table Table
+		cells []string
+	}{
+		{
+			name: "narrow width",
+			table: NewTable().
+				Headers("Name", "Desc", "N").
+				Row("Lip Gloss", "Style definitions for nice terminal layouts", "4000").
+				Width(30),
+			cells: []string{"Name", "Desc", "N", "LipGloss", "Styledefinitionsforniceterminallayouts", "4000"},
+		},
+		{
+			name: "wide unbreakable cell",
+			table: NewTable().
+				Headers("ID", "Description").
+				Row("12345678", "a fairly long description of the item").
+				Width(24),
+			cells: []string{"ID", "Description", "12345678", "afairlylongdescriptionoftheitem"},
+		},
+		{
+			name: "max width below the widest word",
+			table: NewTable().
+				Row("Supercalifragilistic").
+				MaxWidth(0, 8),
+			cells: []string{"Supercalifragilistic"},
+		},
+	}
+
+	// Borders and the white space at which cells wrap are removed, so the
+	// text of each cell reads on in one piece.
+	strip := strings.NewReplacer(" ", "", "\n", "", "│", "", "─", "", "┼", "",
+		"┌", "", "┐", "", "└", "", "┘", "", "├", "", "┤", "", "┬", "", "┴", "")
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.table.Render()
+			for _, l := range strings.Split(got, "\n") {
+				if w := Width(l); tc.table.width > 0 && w > tc.table.width {
+					t.Errorf("line %q is %d wide, want at most %d", l, w, tc.table.width)
+				}
+			}
+
+			// Columns are read top to bottom, one at a time.
+			text := columnText(got)
+			for _, c := range tc.cells {
+				if !strings.Contains(strip.Replace(text), c) {
+					t.Errorf("cell %q is missing from:\n%s", c, got)
+				}
+			}
+		})
+	}
+}
+
+// columnText returns the text of a rendered table column by column, so that
+// cells wrapped over several lines read on.
+func columnText(table string) string {
+	var (
+		lines = strings.Split(table, "\n")
+		b     strings.Builder
+	)
+	var cols [][]string
+	for _, l := range lines {
+		for c, cell := range strings.Split(l, "│") {
+			for len(cols) <= c {
+				cols = append(cols, nil)
+			}
+			cols[c] = append(cols[c], cell)
+		}
+	}
+	for _, col := range cols {
+		b.WriteString(strings.Join(col, " "))
+	}
+	return b.String()
+}
@@ -0,0 +1,48 @@
+package lipgloss
+
+import "strings"
+
+// NoTabConversion can be passed to Style.TabWidth, or set as DefaultTabWidth,
+// to leave tabs in the text untouched.
+const NoTabConversion = -1
+
+// DefaultTabWidth is the distance between tab stops used when expanding tabs
+// into spaces. It applies to styles without a tab width of their own, and to
+// measuring, joining and placing text. Most terminals use a tab width of 8.
+var DefaultTabWidth = 8
+
+// expandTabs replaces tabs with spaces up to the next tab stop. Tab stops are
+// placed every tabWidth cells, starting from the beginning of each line. ANSI
+// sequences don't count towards the position of tab stops. A tab width of 0
+// removes tabs, and NoTabConversion leaves them as they are.
+func expandTabs(str string, tabWidth int) string {
+	if tabWidth < 0 || !strings.ContainsRune(str, '\t') {
+		return str
+	}
+
+	var (
+		b   strings.Builder
+		col int
+		r   = newClusterReader(str)
+	)
+
+	for r.next() {
+		switch {
+		case r.seq:
+		case r.cluster == "\n", r.cluster == "\r", r.cluster == "\r\n":
+			col = 0
+		case r.cluster == "\t":
+			if tabWidth > 0 {
+				n := tabWidth - col%tabWidth
+				b.WriteString(strings.Repeat(" ", n))
+				col += n
+			}
+			continue
+		default:
+			col += r.width
+		}
+		b.WriteString(r.cluster)
+	}
+
+	return b.String()
+}
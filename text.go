@@ -0,0 +1,166 @@
+package lipgloss
+
+import "strings"
+
+// Span is a run of text with a style of its own.
+type Span struct {
+	Style Style
+	Text  string
+
+	// Link is a URL the span links to. Terminals which support hyperlinks
+	// make the text clickable.
+	Link string
+}
+
+const hyperlinkEnd = "\x1b]8;;\x1b\\"
+
+// hyperlink returns the OSC 8 sequence which starts a hyperlink to url.
+func hyperlink(url string) string {
+	return "\x1b]8;;" + url + "\x1b\\"
+}
+
+// Text is a paragraph of rich text made up of spans, each of which can be
+// styled differently. Render it with Style.RenderText.
+//
+// Example:
+//
+//     t := lipgloss.NewText().
+//         Plain("Build ").
+//         Span(lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("9")), "failed").
+//         Plain(" after 3 minutes")
+//
+type Text []Span
+
+// NewText returns rich text made up of the given spans.
+func NewText(spans ...Span) Text {
+	return Text(spans)
+}
+
+// Span appends text with the given style.
+func (t Text) Span(s Style, text string) Text {
+	return append(t, Span{Style: s, Text: text})
+}
+
+// Link appends text with the given style which links to url.
+func (t Text) Link(s Style, text, url string) Text {
+	return append(t, Span{Style: s, Text: text, Link: url})
+}
+
+// Plain appends text without a style of its own. It's still rendered with
+// the text formatting of the container.
+func (t Text) Plain(text string) Text {
+	return append(t, Span{Text: text})
+}
+
+// String returns the text without any styling.
+func (t Text) String() string {
+	var b strings.Builder
+	for _, sp := range t {
+		b.WriteString(sp.Text)
+	}
+	return b.String()
+}
+
+// Properties that apply to text, as opposed to the block it's in. Only these
+// are used when rendering spans.
+var textKeys = []propKey{
+	boldKey,
+	italicKey,
+	underlineKey,
+	strikethroughKey,
+	reverseKey,
+	blinkKey,
+	faintKey,
+	foregroundKey,
+	backgroundKey,
+	underlineSpacesKey,
+	strikethroughSpacesKey,
+}
+
+// RenderText renders rich text as a single paragraph. The container style
+// wraps, aligns, pads and borders the text as a whole, just like Render,
+// while each span keeps its own style across line breaks. Spans inherit any
+// text formatting, like colors and bold, they don't set themselves from the
+// container.
+func (s Style) RenderText(t Text) string {
+	return s.Render(t.render(s))
+}
+
+// render styles each span and returns the text, ready for the container to
+// render. Spans are styled in chunks between line break opportunities, so
+// that no styled run is ever broken across lines by wrapping.
+func (t Text) render(container Style) string {
+	var (
+		b     strings.Builder
+		chunk strings.Builder
+		lb    lineBreaker
+		st    Style
+		link  string
+		space bool // whether the current chunk is whitespace
+	)
+
+	flush := func() {
+		if chunk.Len() == 0 {
+			return
+		}
+		if link != "" {
+			b.WriteString(hyperlink(link))
+		}
+		b.WriteString(st.Render(chunk.String()))
+		if link != "" {
+			b.WriteString(hyperlinkEnd)
+		}
+		chunk.Reset()
+	}
+
+	for _, sp := range t {
+		flush()
+		link = sp.Link
+
+		// Tabs are expanded by the container, which knows their columns.
+		st = NewStyle().TabWidth(NoTabConversion)
+		for _, k := range textKeys {
+			if v, ok := sp.Style.rules[k]; ok {
+				st.rules[k] = v
+			} else if v, ok := container.rules[k]; ok {
+				st.rules[k] = v
+			}
+		}
+
+		for r := newClusterReader(sp.Text); r.next(); {
+			if r.seq {
+				chunk.WriteString(r.cluster)
+				continue
+			}
+
+			var (
+				action breakAction
+				first  rune
+			)
+			for i, c := range r.cluster {
+				a := lb.next(c)
+				if i == 0 {
+					action, first = a, c
+				}
+			}
+
+			// Newlines are left unstyled so they're never inside a
+			// styled run.
+			if first == '\n' || first == '\r' {
+				flush()
+				b.WriteString(r.cluster)
+				continue
+			}
+
+			isSpace := isBreakingSpace(first)
+			if action != noBreak || isSpace != space {
+				flush()
+			}
+			space = isSpace
+			chunk.WriteString(r.cluster)
+		}
+	}
+	flush()
+
+	return b.String()
+}
@@ -0,0 +1,187 @@
+package lipgloss
+
+import (
+	"strings"
+
+	"github.com/muesli/termenv"
+)
+
+// TreeGuides contains the guides drawn in front of the nodes of a Tree.
+type TreeGuides struct {
+	// Branch is drawn in front of a node with more nodes after it, and Last
+	// in front of the last node.
+	Branch string
+	Last   string
+
+	// Line is drawn in front of the lines below a node with more nodes
+	// after it, and Space in front of the lines below the last node.
+	Line  string
+	Space string
+}
+
+var (
+	normalTreeGuides = TreeGuides{
+		Branch: "├── ",
+		Last:   "└── ",
+		Line:   "│   ",
+		Space:  "    ",
+	}
+
+	roundedTreeGuides = TreeGuides{
+		Branch: "├── ",
+		Last:   "╰── ",
+		Line:   "│   ",
+		Space:  "    ",
+	}
+
+	asciiTreeGuides = TreeGuides{
+		Branch: "|-- ",
+		Last:   "`-- ",
+		Line:   "|   ",
+		Space:  "    ",
+	}
+)
+
+// NormalTreeGuides returns guides drawn with box-drawing characters. These
+// are the default.
+func NormalTreeGuides() TreeGuides {
+	return normalTreeGuides
+}
+
+// RoundedTreeGuides returns guides with a rounded corner in front of the
+// last node.
+func RoundedTreeGuides() TreeGuides {
+	return roundedTreeGuides
+}
+
+// ASCIITreeGuides returns guides drawn with ASCII characters.
+func ASCIITreeGuides() TreeGuides {
+	return asciiTreeGuides
+}
+
+// Tree renders hierarchical data, such as files or dependencies, with guides
+// connecting each node to its children. Nodes can span several lines, and
+// each node has a style of its own.
+//
+// Example:
+//
+//     t := lipgloss.NewTree(".").
+//         Child(
+//             lipgloss.NewTree("src").Items("main.go", "tree.go"),
+//             lipgloss.NewTree("README.md").Style(readmeStyle),
+//         ).
+//         EnumeratorStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("8")))
+//
+//     fmt.Println(t.Render())
+//
+type Tree struct {
+	root        string
+	style       Style
+	children    []Tree
+	guides      *TreeGuides
+	enumStyle   Style
+	indentStyle Style
+}
+
+// NewTree returns a new tree with the given text at its root. A tree with an
+// empty root renders only its children.
+func NewTree(root string) Tree {
+	return Tree{root: root}
+}
+
+// Item adds a child node without any children of its own.
+func (t Tree) Item(str string) Tree {
+	return t.Child(NewTree(str))
+}
+
+// Items adds child nodes without any children of their own.
+func (t Tree) Items(items ...string) Tree {
+	for _, str := range items {
+		t = t.Item(str)
+	}
+	return t
+}
+
+// Child adds child trees.
+func (t Tree) Child(children ...Tree) Tree {
+	t.children = append(append([]Tree{}, t.children...), children...)
+	return t
+}
+
+// Style sets the style the root of the tree is rendered with.
+func (t Tree) Style(s Style) Tree {
+	t.style = s
+	return t
+}
+
+// Guides sets the guides drawn in front of the nodes. They're used for the
+// whole tree, including its children. Under the Ascii color profile the
+// default and rounded guides fall back to ASCIITreeGuides; guides of your
+// own are used as they are.
+func (t Tree) Guides(g TreeGuides) Tree {
+	t.guides = &g
+	return t
+}
+
+// EnumeratorStyle sets the style of the guides in front of the first line of
+// each node, Branch and Last. It's used for the whole tree.
+func (t Tree) EnumeratorStyle(s Style) Tree {
+	t.enumStyle = s
+	return t
+}
+
+// IndentStyle sets the style of the guides in front of the lines below each
+// node, Line and Space. It's used for the whole tree.
+func (t Tree) IndentStyle(s Style) Tree {
+	t.indentStyle = s
+	return t
+}
+
+// String implements fmt.Stringer.
+func (t Tree) String() string {
+	return t.Render()
+}
+
+// Render renders the tree.
+func (t Tree) Render() string {
+	g := normalTreeGuides
+	if t.guides != nil {
+		g = *t.guides
+	}
+	if ColorProfile() == termenv.Ascii && (g == normalTreeGuides || g == roundedTreeGuides) {
+		g = asciiTreeGuides
+	}
+
+	var (
+		branch = t.enumStyle.Render(g.Branch)
+		last   = t.enumStyle.Render(g.Last)
+		line   = t.indentStyle.Render(g.Line)
+		space  = t.indentStyle.Render(g.Space)
+	)
+	return strings.Join(t.renderLines(branch, last, line, space), "\n")
+}
+
+// renderLines renders the node and its children with the given, already
+// styled, guides.
+func (t Tree) renderLines(branch, last, line, space string) []string {
+	var lines []string
+	if t.root != "" {
+		lines = strings.Split(t.style.Render(t.root), "\n")
+	}
+
+	for i, c := range t.children {
+		first, rest := branch, line
+		if i == len(t.children)-1 {
+			first, rest = last, space
+		}
+		for k, l := range c.renderLines(branch, last, line, space) {
+			if k == 0 {
+				lines = append(lines, first+l)
+			} else {
+				lines = append(lines, rest+l)
+			}
+		}
+	}
+
+	return lines
+}
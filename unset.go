@@ -66,12 +66,48 @@ func (s Style) UnsetHeight() Style {
 	return s
 }
 
+// UnsetMinWidth removes the minimum width style rule, if set.
+func (s Style) UnsetMinWidth() Style {
+	delete(s.rules, minWidthKey)
+	return s
+}
+
+// UnsetMinHeight removes the minimum height style rule, if set.
+func (s Style) UnsetMinHeight() Style {
+	delete(s.rules, minHeightKey)
+	return s
+}
+
+// UnsetRelativeWidth removes the relative width style rule, if set.
+func (s Style) UnsetRelativeWidth() Style {
+	delete(s.rules, relativeWidthKey)
+	return s
+}
+
+// UnsetRelativeHeight removes the relative height style rule, if set.
+func (s Style) UnsetRelativeHeight() Style {
+	delete(s.rules, relativeHeightKey)
+	return s
+}
+
 // UnsetAlign removes the text alignment style rule, if set.
 func (s Style) UnsetAlign() Style {
 	delete(s.rules, alignKey)
 	return s
 }
 
+// UnsetAlignVertical removes the vertical text alignment style rule, if set.
+func (s Style) UnsetAlignVertical() Style {
+	delete(s.rules, alignVerticalKey)
+	return s
+}
+
+// UnsetJustify removes the text justification style rule, if set.
+func (s Style) UnsetJustify() Style {
+	delete(s.rules, justifyKey)
+	return s
+}
+
 // UnsetPadding removes all padding style rules.
 func (s Style) UnsetPadding() Style {
 	delete(s.rules, paddingLeftKey)
@@ -105,6 +141,15 @@ func (s Style) UnsetPaddingBottom() Style {
 	return s
 }
 
+// UnsetRelativePadding removes all relative padding style rules.
+func (s Style) UnsetRelativePadding() Style {
+	delete(s.rules, relativePaddingLeftKey)
+	delete(s.rules, relativePaddingRightKey)
+	delete(s.rules, relativePaddingTopKey)
+	delete(s.rules, relativePaddingBottomKey)
+	return s
+}
+
 // UnsetColorWhitespace removes the rule for coloring padding, if set.
 func (s Style) UnsetColorWhitespace() Style {
 	delete(s.rules, colorWhitespaceKey)
@@ -144,6 +189,15 @@ func (s Style) UnsetMarginBottom() Style {
 	return s
 }
 
+// UnsetRelativeMargins removes all relative margin style rules.
+func (s Style) UnsetRelativeMargins() Style {
+	delete(s.rules, relativeMarginLeftKey)
+	delete(s.rules, relativeMarginRightKey)
+	delete(s.rules, relativeMarginTopKey)
+	delete(s.rules, relativeMarginBottomKey)
+	return s
+}
+
 // UnsetMarginBackground removes the margin's background color. Note that the
 // margin's background color can be set from the background color of another
 // style during inheritance.
@@ -286,6 +340,60 @@ func (s Style) UnsetStrikethroughSpaces() Style {
 	return s
 }
 
+// UnsetWrap removes the wrap mode style rule, if set.
+func (s Style) UnsetWrap() Style {
+	delete(s.rules, wrapModeKey)
+	return s
+}
+
+// UnsetInlineSeparator removes the inline separator style rule, if set.
+func (s Style) UnsetInlineSeparator() Style {
+	delete(s.rules, inlineSeparatorKey)
+	return s
+}
+
+// UnsetInlineSpacing removes the inline spacing style rule, if set.
+func (s Style) UnsetInlineSpacing() Style {
+	delete(s.rules, inlineSpacingKey)
+	return s
+}
+
+// UnsetWhiteSpace removes the white space style rule, if set.
+func (s Style) UnsetWhiteSpace() Style {
+	delete(s.rules, whiteSpaceKey)
+	return s
+}
+
+// UnsetHangingIndent removes the hanging indent style rule, if set.
+func (s Style) UnsetHangingIndent() Style {
+	delete(s.rules, hangingIndentKey)
+	return s
+}
+
+// UnsetWrapPrefix removes the wrap prefix style rule, if set.
+func (s Style) UnsetWrapPrefix() Style {
+	delete(s.rules, wrapPrefixKey)
+	return s
+}
+
+// UnsetTabWidth removes the tab width style rule, if set.
+func (s Style) UnsetTabWidth() Style {
+	delete(s.rules, tabWidthKey)
+	return s
+}
+
+// UnsetKeepLineEndings removes the rule for keeping line endings, if set.
+func (s Style) UnsetKeepLineEndings() Style {
+	delete(s.rules, keepLineEndingsKey)
+	return s
+}
+
+// UnsetDirection removes the text direction style rule, if set.
+func (s Style) UnsetDirection() Style {
+	delete(s.rules, directionKey)
+	return s
+}
+
 // UnsetString sets the underlying string value to the empty string.
 func (s Style) UnsetString() Style {
 	s.value = ""
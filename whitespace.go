@@ -3,7 +3,6 @@ package lipgloss
 import (
 	"strings"
 
-	"github.com/muesli/reflow/ansi"
 	"github.com/muesli/termenv"
 )
 
@@ -11,6 +10,10 @@ import (
 type whitespace struct {
 	style termenv.Style
 	chars string
+
+	// Used when joining blocks.
+	gap       int
+	separator string
 }
 
 // Render whitespaces.
@@ -19,23 +22,36 @@ func (w whitespace) render(width int) string {
 		w.chars = " "
 	}
 
-	r := []rune(w.chars)
-	j := 0
-	b := strings.Builder{}
+	var (
+		chars []string // grapheme clusters in chars
+		b     strings.Builder
+		j     int
+	)
+	for r := newClusterReader(w.chars); r.next(); {
+		if r.width > 0 {
+			chars = append(chars, r.cluster)
+		}
+	}
+	if len(chars) == 0 {
+		chars = []string{" "}
+	}
 
-	// Cycle through runes and print them into the whitespace.
+	// Cycle through characters and print them into the whitespace.
 	for i := 0; i < width; {
-		b.WriteRune(r[j])
-		j++
-		if j >= len(r) {
-			j = 0
+		c := chars[j]
+		cw := stringWidth(c)
+		if i+cw > width {
+			break
 		}
-		i += ansi.PrintableRuneWidth(string(r[j]))
+		b.WriteString(c)
+		i += cw
+		j = (j + 1) % len(chars)
 	}
 
-	// Fill any extra gaps white spaces. This might be necessary if any runes
-	// are more than one cell wide, which could leave a one-rune gap.
-	short := width - ansi.PrintableRuneWidth(b.String())
+	// Fill any extra gaps white spaces. This might be necessary if any
+	// characters are more than one cell wide, which could leave a one-cell
+	// gap.
+	short := width - stringWidth(b.String())
 	if short > 0 {
 		b.WriteString(strings.Repeat(" ", short))
 	}
@@ -43,6 +59,14 @@ func (w whitespace) render(width int) string {
 	return w.style.Styled(b.String())
 }
 
+// fill renders whitespace of the given width, or nothing if the width is 0.
+func (w whitespace) fill(width int) string {
+	if width <= 0 {
+		return ""
+	}
+	return w.render(width)
+}
+
 // WhiteSpaceOption sets a styling rule for rendering whitespace.
 type WhitespaceOption func(*whitespace)
 
@@ -66,3 +90,24 @@ func WithWhitespaceChars(s string) WhitespaceOption {
 		w.chars = s
 	}
 }
+
+// WithGap sets the number of cells between blocks joined with
+// JoinHorizontalWith, or the number of lines between blocks joined with
+// JoinVerticalWith. The gap is filled with whitespace and, if there's a
+// separator, placed on both sides of it. It has no effect on Place.
+func WithGap(n int) WhitespaceOption {
+	return func(w *whitespace) {
+		w.gap = max(0, n)
+	}
+}
+
+// WithSeparator sets a separator to put between joined blocks. Blocks joined
+// with JoinHorizontalWith have the separator between them on every line,
+// and blocks joined with JoinVerticalWith have a line of the separator,
+// repeated across the width, between them. It can be styled. It has no
+// effect on Place.
+func WithSeparator(sep string) WhitespaceOption {
+	return func(w *whitespace) {
+		w.separator = sep
+	}
+}
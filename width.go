@@ -0,0 +1,254 @@
+package lipgloss
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/muesli/reflow/ansi"
+	"github.com/rivo/uniseg"
+)
+
+const (
+	textPresentation  = '\uFE0E' // variation selector 15
+	emojiPresentation = '\uFE0F' // variation selector 16
+)
+
+var (
+	ambiguousWide      bool
+	checkAmbiguousWide sync.Once
+
+	narrowCondition = &runewidth.Condition{EastAsianWidth: false}
+	wideCondition   = &runewidth.Condition{EastAsianWidth: true}
+)
+
+// AmbiguousWide returns whether characters of ambiguous East Asian width, like
+// "…", "°", Greek letters and box drawing characters, are treated as wide,
+// taking up two cells. Terminals in CJK locales often draw them wide.
+//
+// Unless set with SetAmbiguousWide this is detected from the locale set in
+// the LC_ALL, LC_CTYPE and LANG environment variables, and can be overridden
+// with RUNEWIDTH_EASTASIAN. The actual check is performed only once.
+func AmbiguousWide() bool {
+	checkAmbiguousWide.Do(func() {
+		ambiguousWide = runewidth.EastAsianWidth
+	})
+	return ambiguousWide
+}
+
+// SetAmbiguousWide sets whether characters of ambiguous East Asian width are
+// treated as wide, overriding detection. This affects all measuring, wrapping
+// and border drawing. Set it before rendering anything.
+func SetAmbiguousWide(v bool) {
+	checkAmbiguousWide.Do(func() {})
+	ambiguousWide = v
+}
+
+// runeWidth returns the cell width of a single rune.
+func runeWidth(r rune) int {
+	if AmbiguousWide() {
+		return wideCondition.RuneWidth(r)
+	}
+	return narrowCondition.RuneWidth(r)
+}
+
+// clusterReader reads a string one ANSI sequence or extended grapheme cluster
+// at a time. Grapheme clusters are what users perceive as single characters,
+// such as emoji made up of several code points joined by zero width joiners,
+// flags and characters with combining marks, and must never be split.
+type clusterReader struct {
+	str string
+	pos int // start of the remaining, unread portion of str
+
+	graphemes *uniseg.Graphemes // graphemes in the current run of text
+	runStart  int               // start of the current run of text
+
+	cluster string // the current cluster or sequence
+	start   int    // byte offset of the current cluster
+	width   int    // cell width of the current cluster
+	seq     bool   // whether the current cluster is an ANSI sequence
+}
+
+func newClusterReader(str string) *clusterReader {
+	return &clusterReader{str: str}
+}
+
+// next advances to the next cluster, returning false once there are none
+// left.
+func (r *clusterReader) next() bool {
+	if r.graphemes != nil && r.graphemes.Next() {
+		from, to := r.graphemes.Positions()
+		r.start = r.runStart + from
+		r.cluster = r.str[r.start : r.runStart+to]
+		r.width = clusterWidth(r.cluster)
+		r.seq = false
+		return true
+	}
+	r.graphemes = nil
+
+	if r.pos >= len(r.str) {
+		return false
+	}
+
+	// ANSI sequence
+	if r.str[r.pos] == ansi.Marker {
+		end := sequenceEnd(r.str, r.pos)
+		r.start = r.pos
+		r.cluster = r.str[r.pos:end]
+		r.width = 0
+		r.seq = true
+		r.pos = end
+		return true
+	}
+
+	// A run of text up to the next ANSI sequence
+	end := strings.IndexRune(r.str[r.pos:], ansi.Marker)
+	if end < 0 {
+		end = len(r.str)
+	} else {
+		end += r.pos
+	}
+	r.graphemes = uniseg.NewGraphemes(r.str[r.pos:end])
+	r.runStart = r.pos
+	r.pos = end
+
+	return r.next()
+}
+
+// sequenceEnd returns the end of the ANSI sequence starting at the given byte
+// offset. Operating system commands, like hyperlinks, end in a bell or string
+// terminator; other sequences end in a letter.
+func sequenceEnd(str string, start int) int {
+	if strings.HasPrefix(str[start:], "\x1b]") {
+		for i := start + 2; i < len(str); i++ {
+			if str[i] == '\a' {
+				return i + 1
+			}
+			if str[i] == ansi.Marker && i+1 < len(str) && str[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return len(str)
+	}
+
+	for i, c := range str[start+1:] {
+		if ansi.IsTerminator(c) {
+			return start + 1 + i + len(string(c))
+		}
+	}
+	return len(str)
+}
+
+// clusterWidth returns the cell width of a single grapheme cluster. The width
+// of a cluster is the width of its first visible rune, adjusted by the
+// presentation rules for emoji: variation selectors switch between emoji
+// (wide) and text (narrow) presentation, and pairs of regional indicators form
+// flags, which are wide.
+func clusterWidth(c string) int {
+	var (
+		w   int
+		ri  int
+		vs  rune
+		set bool
+	)
+
+	for _, r := range c {
+		switch {
+		case r == textPresentation, r == emojiPresentation:
+			vs = r
+		case r >= 0x1F1E6 && r <= 0x1F1FF:
+			ri++
+			fallthrough
+		case !set:
+			if rw := runeWidth(r); rw > 0 {
+				w = rw
+				set = true
+			}
+		}
+	}
+
+	switch {
+	case w == 0:
+		return 0
+	case ri == 2:
+		return 2
+	case vs == emojiPresentation:
+		return 2
+	case vs == textPresentation:
+		return 1
+	}
+	return w
+}
+
+// stringWidth returns the cell width of a string, ignoring ANSI sequences.
+// Strings are measured by their grapheme clusters rather than their runes.
+func stringWidth(str string) (width int) {
+	r := newClusterReader(str)
+	for r.next() {
+		width += r.width
+	}
+	return width
+}
+
+// truncateRight truncates a string to the given cell width. ANSI sequences
+// are never cut, and any attributes or hyperlink in effect where the string
+// is cut off are closed, so styles are terminated properly. If a wide
+// character doesn't fit it's replaced by spaces.
+func truncateRight(str string, n int) string {
+	var (
+		b     strings.Builder
+		w     int
+		r     = newClusterReader(str)
+		state sgrState
+	)
+
+	for r.next() {
+		switch {
+		case r.seq:
+			b.WriteString(r.cluster)
+			state.update(r.cluster)
+		case w+r.width <= n:
+			b.WriteString(r.cluster)
+			w += r.width
+		default:
+			// Cut off the rest of the string, including any sequences in
+			// it, and close what's still open.
+			b.WriteString(strings.Repeat(" ", n-w))
+			b.WriteString(state.end())
+			return b.String()
+		}
+	}
+
+	return b.String()
+}
+
+// truncateLeft removes the given number of cells from the beginning of a
+// string. ANSI sequences in the removed portion are kept so that styles carry
+// over. If a wide character is cut in half the remaining half is replaced
+// with a space.
+func truncateLeft(str string, n int) string {
+	if n <= 0 {
+		return str
+	}
+
+	var (
+		b strings.Builder
+		w int
+		r = newClusterReader(str)
+	)
+
+	for r.next() {
+		if r.seq {
+			b.WriteString(r.cluster)
+			continue
+		}
+		w += r.width
+		if w >= n {
+			b.WriteString(strings.Repeat(" ", w-n))
+			b.WriteString(str[r.start+len(r.cluster):])
+			break
+		}
+	}
+
+	return b.String()
+}
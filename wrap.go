@@ -0,0 +1,457 @@
+package lipgloss
+
+import (
+	"strings"
+	"unicode"
+)
+
+// WrapMode determines how text is wrapped when it's wider than the width set
+// on a style.
+type WrapMode int
+
+// Available wrap modes.
+const (
+	// WrapWords wraps text at word boundaries. Words wider than the available
+	// width are left intact and will overflow the block. This is the default.
+	WrapWords WrapMode = iota
+
+	// WrapBreakWords wraps text at word boundaries and additionally breaks
+	// words wider than the available width at cell boundaries.
+	WrapBreakWords
+)
+
+// WhiteSpace determines how white space in text is handled. The modes are
+// modeled on the CSS white-space property.
+type WhiteSpace int
+
+// Available white space modes.
+const (
+	// WhiteSpaceNormal collapses runs of spaces, tabs and newlines into a
+	// single space, removes white space at the beginning and end of the text
+	// and wraps lines to the width.
+	WhiteSpaceNormal WhiteSpace = iota
+
+	// WhiteSpaceNoWrap collapses white space like WhiteSpaceNormal, but never
+	// wraps lines.
+	WhiteSpaceNoWrap
+
+	// WhiteSpacePre keeps all white space and never wraps lines. Lines wider
+	// than the width overflow the block.
+	WhiteSpacePre
+
+	// WhiteSpacePreWrap keeps all white space and wraps lines to the width.
+	// Spaces at which lines are wrapped are dropped. This is the default.
+	WhiteSpacePreWrap
+)
+
+// collapses returns whether runs of white space are collapsed.
+func (w WhiteSpace) collapses() bool {
+	return w == WhiteSpaceNormal || w == WhiteSpaceNoWrap
+}
+
+// wraps returns whether lines are wrapped to the width.
+func (w WhiteSpace) wraps() bool {
+	return w == WhiteSpaceNormal || w == WhiteSpacePreWrap
+}
+
+const softHyphen = "\u00ad"
+
+// wrapper contains the settings for wrapping a block of text.
+type wrapper struct {
+	width  int      // width of the block
+	mode   WrapMode // how to deal with long words
+	indent int      // hanging indent for continuation lines
+	prefix string   // prefix for continuation lines
+}
+
+// A segment is a portion of a line between two line break opportunities,
+// with any trailing whitespace split off. Lines may be broken between
+// segments, in which case the trailing whitespace is dropped.
+type segment struct {
+	text  string
+	space string
+
+	// Whether the line must be broken after this segment.
+	hard bool
+}
+
+// wrap wraps every line in the given string to the width of the wrapper.
+// Existing newlines are kept.
+func (w wrapper) wrap(str string) string {
+	lines, _, _ := w.lines(str)
+	return strings.Join(lines, "\n")
+}
+
+// lines wraps every line in the given string to the width of the wrapper and
+// returns the resulting lines. For each line we also report whether it was
+// broken by wrapping, as opposed to ending in a newline or a mandatory break,
+// and the width of the hanging indent and prefix it starts with.
+func (w wrapper) lines(str string) (lines []string, wrapped []bool, indents []int) {
+	if w.width < 1 {
+		lines = strings.Split(str, "\n")
+		return lines, make([]bool, len(lines)), make([]int, len(lines))
+	}
+
+	// If there's no room left for content on continuation lines, ignore the
+	// hanging indent and prefix.
+	if w.width-w.indent-stringWidth(w.prefix) < 1 {
+		w.indent = 0
+		w.prefix = ""
+	}
+
+	for _, l := range strings.Split(str, "\n") {
+		ls, ws, is := w.wrapLine(l)
+		lines = append(lines, ls...)
+		wrapped = append(wrapped, ws...)
+		indents = append(indents, is...)
+	}
+
+	return lines, wrapped, indents
+}
+
+// wrapLine wraps a single line, returning the resulting lines, whether each
+// was broken by wrapping and the width of the indent and prefix of each.
+func (w wrapper) wrapLine(str string) (lines []string, wrapped []bool, indents []int) {
+	var (
+		b strings.Builder
+
+		lineWidth  int    // width of the current line
+		startWidth int    // width of the indent and prefix on the current line
+		empty      = true // whether we've written any segments to the current line
+		hyphen     bool   // whether the last segment ended in a soft hyphen
+		hard       bool   // whether the last segment requires a line break
+		space      string
+		spaceWidth int
+		contPrefix = strings.Repeat(" ", w.indent) + w.prefix
+		contWidth  = stringWidth(contPrefix)
+	)
+
+	newLine := func(soft bool) {
+		if hyphen {
+			b.WriteRune('-')
+		}
+		b.WriteString(ansiSequences(space)) // keep styles from dropped spaces
+		lines = append(lines, b.String())
+		wrapped = append(wrapped, soft)
+		indents = append(indents, startWidth)
+		b.Reset()
+		b.WriteString(contPrefix)
+		lineWidth = contWidth
+		startWidth = contWidth
+		empty = true
+		hyphen = false
+		space = ""
+		spaceWidth = 0
+	}
+
+	for _, seg := range segments(str) {
+		text := seg.text
+
+		// Soft hyphens are only shown, as regular hyphens, when a line is
+		// broken after them. We make sure there's room for one.
+		text, shy := trimSoftHyphens(text)
+		textWidth := stringWidth(text)
+		if shy {
+			textWidth++
+		}
+
+		// Break before this segment if it's preceded by a mandatory break or
+		// doesn't fit on the current line.
+		if hard {
+			newLine(false)
+		} else if !empty && lineWidth+spaceWidth+textWidth > w.width {
+			newLine(true)
+		}
+
+		b.WriteString(space)
+		lineWidth += spaceWidth
+		hyphen = false
+
+		// Break up words that are too wide to fit on a line of their own.
+		if w.mode == WrapBreakWords && lineWidth+textWidth > w.width {
+			for {
+				// Leave room for the hyphen after the last part.
+				avail := w.width - lineWidth
+				if tw := stringWidth(text); shy && tw <= avail && tw+1 > avail {
+					avail--
+				}
+
+				var head string
+				head, text = splitAtWidth(text, avail, lineWidth == startWidth)
+				b.WriteString(head)
+				lineWidth += stringWidth(head)
+				if text == "" {
+					break
+				}
+				newLine(true)
+			}
+		} else {
+			b.WriteString(text)
+			lineWidth += stringWidth(text)
+		}
+
+		if seg.text != "" {
+			empty = false
+		}
+		hyphen = shy
+		hard = seg.hard
+		space = seg.space
+		spaceWidth = stringWidth(space)
+	}
+
+	// Keep trailing whitespace as long as it fits.
+	if lineWidth+spaceWidth <= w.width {
+		b.WriteString(space)
+	} else {
+		b.WriteString(ansiSequences(space))
+	}
+
+	return append(lines, b.String()), append(wrapped, false), append(indents, startWidth)
+}
+
+// trimSoftHyphens removes the soft hyphens from the text of a segment,
+// returning whether it ended in one. Soft hyphens within a segment aren't at a
+// line break opportunity, so they're never shown.
+func trimSoftHyphens(text string) (string, bool) {
+	if !strings.Contains(text, softHyphen) {
+		return text, false
+	}
+
+	var (
+		b   strings.Builder
+		shy bool
+	)
+	for r := newClusterReader(text); r.next(); {
+		switch {
+		case r.seq:
+			b.WriteString(r.cluster)
+		case r.cluster == softHyphen:
+			shy = true
+		default:
+			b.WriteString(r.cluster)
+			shy = false
+		}
+	}
+	return b.String(), shy
+}
+
+// segments breaks a line into segments at the line break opportunities
+// defined in UAX #14. Grapheme clusters are never broken up. ANSI sequences
+// are kept with the text that follows them.
+func segments(str string) []segment {
+	var (
+		segs  []segment
+		lb    lineBreaker
+		text  strings.Builder
+		space strings.Builder
+		seq   strings.Builder // pending ANSI sequence(s)
+	)
+
+	flush := func(hard bool) {
+		if text.Len() == 0 && space.Len() == 0 {
+			return
+		}
+		segs = append(segs, segment{text: text.String(), space: space.String(), hard: hard})
+		text.Reset()
+		space.Reset()
+	}
+
+	for r := newClusterReader(str); r.next(); {
+		if r.seq {
+			// Resets close what came before them, so they stay with the
+			// preceding text or whitespace.
+			switch {
+			case seq.Len() == 0 && isReset(r.cluster) && space.Len() > 0:
+				space.WriteString(r.cluster)
+			case seq.Len() == 0 && isReset(r.cluster) && text.Len() > 0:
+				text.WriteString(r.cluster)
+			default:
+				seq.WriteString(r.cluster)
+			}
+			continue
+		}
+
+		// Only the first rune of a cluster can start a new segment, but the
+		// line breaker needs to see all of them.
+		var (
+			action breakAction
+			first  rune
+		)
+		for i, c := range r.cluster {
+			if i == 0 {
+				action = lb.next(c)
+				first = c
+				continue
+			}
+			lb.next(c)
+		}
+		if action != noBreak {
+			flush(action == mustBreak)
+		}
+
+		if isBreakingSpace(first) {
+			space.WriteString(seq.String())
+			space.WriteString(r.cluster)
+			seq.Reset()
+			continue
+		}
+
+		// Whitespace followed by a character we can't break before becomes
+		// part of the text.
+		if space.Len() > 0 {
+			text.WriteString(space.String())
+			space.Reset()
+		}
+		text.WriteString(seq.String())
+		text.WriteString(r.cluster)
+		seq.Reset()
+	}
+
+	text.WriteString(seq.String())
+	flush(false)
+
+	return segs
+}
+
+// collapseWhiteSpace collapses runs of spaces, tabs and newlines into a
+// single space and removes white space at the beginning and end of a string.
+// ANSI sequences are kept.
+func collapseWhiteSpace(str string) string {
+	var (
+		b     strings.Builder
+		space bool // whether there's white space to write before the next text
+		text  bool // whether we've written any text yet
+	)
+
+	for r := newClusterReader(str); r.next(); {
+		switch {
+		case r.seq:
+			b.WriteString(r.cluster)
+		case r.cluster == " " || r.cluster == "\t" || r.cluster == "\n":
+			space = text
+		default:
+			if space {
+				b.WriteByte(' ')
+				space = false
+			}
+			b.WriteString(r.cluster)
+			text = true
+		}
+	}
+
+	return b.String()
+}
+
+// ansiSequences returns only the ANSI sequences in a string.
+func ansiSequences(str string) string {
+	var b strings.Builder
+	for r := newClusterReader(str); r.next(); {
+		if r.seq {
+			b.WriteString(r.cluster)
+		}
+	}
+	return b.String()
+}
+
+// sgrState tracks the text attributes and hyperlink in effect at some point
+// in a string of styled text.
+type sgrState struct {
+	sgr  string // sequences setting attributes since the last reset
+	link string // the sequence starting the current hyperlink
+}
+
+// update updates the state with an ANSI sequence.
+func (s *sgrState) update(seq string) {
+	switch {
+	case isSGRReset(seq):
+		s.sgr = ""
+	case isSGR(seq):
+		s.sgr += seq
+	case seq == hyperlinkEnd:
+		s.link = ""
+	case strings.HasPrefix(seq, "\x1b]8;"):
+		s.link = seq
+	}
+}
+
+// start returns the sequences which restore the state.
+func (s sgrState) start() string {
+	return s.link + s.sgr
+}
+
+// end returns the sequences which close any attributes and hyperlink in
+// effect.
+func (s sgrState) end() string {
+	var e string
+	if s.sgr != "" {
+		e += "\x1b[0m"
+	}
+	if s.link != "" {
+		e += hyperlinkEnd
+	}
+	return e
+}
+
+// carryStyles makes each line of styled text stand on its own. Attributes and
+// hyperlinks still in effect at the end of a line are closed there and
+// reopened at the start of the next line, so they don't bleed into whatever
+// is placed next to the line, like padding and borders.
+func carryStyles(lines []string) []string {
+	var state sgrState
+
+	for i, l := range lines {
+		start := state.start()
+		if strings.IndexByte(l, '\x1b') >= 0 {
+			for r := newClusterReader(l); r.next(); {
+				if r.seq {
+					state.update(r.cluster)
+				}
+			}
+		}
+		lines[i] = start + l + state.end()
+	}
+
+	return lines
+}
+
+// isReset returns whether an ANSI sequence resets all text attributes, or ends
+// a hyperlink.
+func isReset(seq string) bool {
+	return isSGRReset(seq) || seq == hyperlinkEnd
+}
+
+// isSGRReset returns whether an ANSI sequence resets all text attributes.
+func isSGRReset(seq string) bool {
+	return seq == "\x1b[0m" || seq == "\x1b[m"
+}
+
+// isSGR returns whether an ANSI sequence sets text attributes.
+func isSGR(seq string) bool {
+	return strings.HasPrefix(seq, "\x1b[") && strings.HasSuffix(seq, "m")
+}
+
+// isBreakingSpace returns whether a rune is whitespace which can be dropped
+// when a line is broken after it.
+func isBreakingSpace(r rune) bool {
+	return unicode.IsSpace(r) && lineBreakClass(r) != lbGL
+}
+
+// splitAtWidth splits a string so that the first part is at most the given
+// number of cells wide. ANSI sequences and grapheme clusters are never split.
+// If force is true at least one character will be put in the first part,
+// even if it's too wide, so that callers are guaranteed to make progress.
+func splitAtWidth(str string, width int, force bool) (head, tail string) {
+	var w int
+
+	for r := newClusterReader(str); r.next(); {
+		if r.seq {
+			continue
+		}
+		if w+r.width > width && !(force && w == 0) {
+			return str[:r.start], str[r.start:]
+		}
+		w += r.width
+	}
+
+	return str, ""
+}